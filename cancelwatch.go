@@ -0,0 +1,143 @@
+package runnable
+
+import (
+	"context"
+	"runtime/pprof"
+	"strings"
+	"time"
+)
+
+// CancelLatency describes the time a runnable took to return after the cancellation
+// of its context, or is still taking. See [CancelWatch].
+type CancelLatency struct {
+	// Name is the name of the runnable, or the name given by its [Manager].
+	Name string
+	// Latency is the time elapsed since the cancellation.
+	Latency time.Duration
+	// Returned is false while the runnable is still running, true once it returned.
+	Returned bool
+	// Slow reports that the latency exceeded the threshold.
+	Slow bool
+	// Goroutines are the stacks of the goroutines of the runnable, while still running.
+	Goroutines string
+}
+
+// CancelWatch returns a runnable that measures the time the runnable takes to return
+// after the cancellation of its context, to find runnables that ignore the cancellation.
+//
+// The latency is measured every time the runnable returns after a cancellation. When
+// the runnable is still running after the threshold, a warning is logged, and the
+// stacks of its goroutines are captured. When it finally returns, the latency is
+// logged. Use [cancelWatch.OnReport] to report the latencies. See
+// [manager.CancelWatch] to watch all the runnables of a manager.
+func CancelWatch(runnable Runnable, threshold time.Duration) *cancelWatch {
+	return &cancelWatch{
+		name:      "cancelwatch/" + runnableName(runnable),
+		runnable:  runnable,
+		threshold: threshold,
+		clock:     systemClock{},
+	}
+}
+
+type cancelWatch struct {
+	name      string
+	runnable  Runnable
+	threshold time.Duration
+	clock     Clock
+	onReport  func(CancelLatency)
+}
+
+var _ Runnable = (*cancelWatch)(nil)
+
+func (w *cancelWatch) runnableName() string { return w.name }
+func (w *cancelWatch) unwrap() Runnable     { return w.runnable }
+
+// OnReport sets a function called with the latency when the runnable returns after a
+// cancellation, and beforehand when it is still running after the threshold. It is
+// called from a different goroutine than Run, but Run does not return before the last
+// call completed.
+func (w *cancelWatch) OnReport(fn func(CancelLatency)) *cancelWatch {
+	w.onReport = fn
+	return w
+}
+
+// Clock sets the clock used to measure the latency. Defaults to the system clock.
+func (w *cancelWatch) Clock(clock Clock) *cancelWatch {
+	w.clock = clock
+	return w
+}
+
+func (w *cancelWatch) Run(ctx context.Context) error {
+	// The goroutines are found by their label: the one set by a manager, or our own.
+	name, labelled := pprof.Label(ctx, runnableLabel)
+	if !labelled {
+		name = w.name
+	}
+
+	done := make(chan struct{})
+	watched := make(chan struct{})
+	go func() {
+		defer close(watched)
+		w.watch(ctx, name, done)
+	}()
+
+	var err error
+	if labelled {
+		err = w.runnable.Run(ctx)
+	} else {
+		pprof.Do(ctx, pprof.Labels(runnableLabel, name), func(ctx context.Context) {
+			err = w.runnable.Run(ctx)
+		})
+	}
+
+	close(done)
+	<-watched
+	return err
+}
+
+// watch waits for the cancellation, then measures the time the runnable takes to
+// return, reporting it if it does not return within the threshold.
+func (w *cancelWatch) watch(ctx context.Context, name string, done <-chan struct{}) {
+	// This goroutine inherited the label, but is not one of the runnable.
+	pprof.SetGoroutineLabels(context.Background())
+
+	select {
+	case <-done:
+		return
+	case <-ctx.Done():
+	}
+	cancelled := w.clock.Now()
+
+	threshold, stop := w.clock.NewTimer(w.threshold)
+	defer stop()
+
+	slow := false
+	select {
+	case <-done:
+	case <-threshold:
+		slow = true
+
+		var goroutines strings.Builder
+		if err := writeGoroutines(&goroutines, name); err != nil {
+			logger.Info(name+": failed to dump goroutines", "error", err)
+		}
+
+		latency := w.clock.Now().Sub(cancelled)
+		logger.Warn(name+": still running after cancellation", "latency", latency)
+		w.report(CancelLatency{Name: name, Latency: latency, Slow: true, Goroutines: goroutines.String()})
+
+		<-done
+	}
+
+	latency := w.clock.Now().Sub(cancelled)
+	if slow {
+		logger.Warn(name+": returned late after cancellation", "latency", latency)
+	}
+	w.report(CancelLatency{Name: name, Latency: latency, Returned: true, Slow: slow})
+}
+
+func (w *cancelWatch) report(latency CancelLatency) {
+	if w.onReport != nil {
+		w.onReport(latency)
+	}
+}
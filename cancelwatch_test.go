@@ -0,0 +1,101 @@
+package runnable
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func slowRunnable(release <-chan struct{}) *funcRunnable {
+	return Func(func(ctx context.Context) error {
+		<-ctx.Done()
+		<-release // ignores the cancellation
+		return ctx.Err()
+	}).Name("slow")
+}
+
+func TestCancelWatch(t *testing.T) {
+	t.Run("slow", func(t *testing.T) {
+		release := make(chan struct{})
+
+		var mu sync.Mutex
+		var reports []CancelLatency
+		w := CancelWatch(slowRunnable(release), 10*time.Millisecond).OnReport(func(latency CancelLatency) {
+			mu.Lock()
+			defer mu.Unlock()
+			reports = append(reports, latency)
+			if !latency.Returned {
+				close(release)
+			}
+		})
+
+		err := w.Run(cancelledContext())
+		require.ErrorIs(t, err, context.Canceled)
+
+		require.Len(t, reports, 2)
+
+		require.Equal(t, "cancelwatch/slow", reports[0].Name)
+		require.False(t, reports[0].Returned)
+		require.True(t, reports[0].Slow)
+		require.GreaterOrEqual(t, reports[0].Latency, 10*time.Millisecond)
+		require.Contains(t, reports[0].Goroutines, `# labels: {"runnable":"cancelwatch/slow"}`)
+		require.Contains(t, reports[0].Goroutines, "slowRunnable.func1")
+		require.NotContains(t, reports[0].Goroutines, "cancelWatch).watch")
+
+		require.True(t, reports[1].Returned)
+		require.True(t, reports[1].Slow)
+		require.GreaterOrEqual(t, reports[1].Latency, reports[0].Latency)
+		require.Empty(t, reports[1].Goroutines)
+	})
+
+	t.Run("in time", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			worker := Func(func(ctx context.Context) error {
+				<-ctx.Done()
+				time.Sleep(3 * time.Second) // cleanup
+				return ctx.Err()
+			}).Name("worker")
+
+			var reports []CancelLatency
+			w := CancelWatch(worker, 10*time.Second).OnReport(func(latency CancelLatency) {
+				reports = append(reports, latency)
+			})
+
+			err := w.Run(cancelledContext())
+			require.ErrorIs(t, err, context.Canceled)
+			require.Equal(t, []CancelLatency{
+				{Name: "cancelwatch/worker", Latency: 3 * time.Second, Returned: true},
+			}, reports)
+		})
+	})
+
+	t.Run("not cancelled", func(t *testing.T) {
+		called := false
+		w := CancelWatch(newDyingRunnable(), time.Second).OnReport(func(CancelLatency) { called = true })
+
+		require.EqualError(t, w.Run(context.Background()), "dying")
+		require.False(t, called)
+	})
+}
+
+func TestManager_CancelWatch(t *testing.T) {
+	release := make(chan struct{})
+	time.AfterFunc(50*time.Millisecond, func() { close(release) })
+
+	var dump lockedBuffer
+	m := Manager().CancelWatch(10 * time.Millisecond).StuckDump(&dump)
+	m.Register(slowRunnable(release), newDummyRunnable())
+
+	err := m.Run(cancelledContext())
+	require.NoError(t, err)
+
+	out := dump.String()
+	require.Contains(t, out, "goroutines of manager/slow:\n")
+	require.Contains(t, out, `# labels: {"runnable":"manager/slow"}`)
+	require.Contains(t, out, "slowRunnable.func1")
+	require.NotContains(t, out, "dummyRunnable")
+}
@@ -0,0 +1,232 @@
+package runnable
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of a [CircuitBreaker].
+type CircuitState int
+
+const (
+	// CircuitClosed runs the runnable normally.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen holds the runnable back until the cooldown has elapsed.
+	CircuitOpen
+	// CircuitHalfOpen runs the runnable once as a trial.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreaker returns a runnable that stops running the given runnable after
+// repeated failures, to avoid hammering a dependency that is down.
+//
+// The circuit opens after [circuitBreaker.FailureThreshold] consecutive failures
+// (default: 5), or when the ratio of failures configured with
+// [circuitBreaker.FailureRatio] is reached. While open, Run waits for the cooldown
+// (default: 30s) without running the inner runnable, then the circuit half-opens and
+// runs it once as a trial: a success closes the circuit, a failure opens it again.
+//
+// Each call to Run executes the inner runnable at most once and returns its error,
+// so CircuitBreaker is meant to be wrapped by [Restart]. Context cancellation is not
+// counted as a failure. The current state is available with [circuitBreaker.State].
+func CircuitBreaker(runnable Runnable) *circuitBreaker {
+	return &circuitBreaker{
+		name:             "circuitbreaker/" + runnableName(runnable),
+		runnable:         runnable,
+		failureThreshold: 5,
+		cooldown:         30 * time.Second,
+		clock:            systemClock{},
+	}
+}
+
+type circuitBreaker struct {
+	name             string
+	runnable         Runnable
+	failureThreshold int
+	failureRatio     float64
+	ratioWindow      int
+	cooldown         time.Duration
+	onStateChange    func(from, to CircuitState)
+	clock            Clock
+
+	mu          sync.Mutex
+	state       CircuitState
+	openedAt    time.Time
+	consecutive int
+	results     []bool // recent results for the failure ratio, true for a failure
+}
+
+var _ Runnable = (*circuitBreaker)(nil)
+
+func (c *circuitBreaker) runnableName() string { return c.name }
+func (c *circuitBreaker) unwrap() Runnable     { return c.runnable }
+
+// Name sets the runnable name, used in log messages. Defaults to "circuitbreaker/<inner>".
+func (c *circuitBreaker) Name(name string) *circuitBreaker {
+	c.name = name
+	return c
+}
+
+// FailureThreshold sets the number of consecutive failures that opens the circuit.
+// Defaults to 5. Zero disables the threshold.
+func (c *circuitBreaker) FailureThreshold(n int) *circuitBreaker {
+	c.failureThreshold = n
+	return c
+}
+
+// FailureRatio opens the circuit when the ratio of failures among the last window
+// runs reaches ratio (between 0 and 1). The ratio is only evaluated once window runs
+// have completed. Disabled by default.
+func (c *circuitBreaker) FailureRatio(ratio float64, window int) *circuitBreaker {
+	c.failureRatio = ratio
+	c.ratioWindow = window
+	return c
+}
+
+// Cooldown sets how long the circuit stays open before a trial run. Defaults to 30 seconds.
+func (c *circuitBreaker) Cooldown(d time.Duration) *circuitBreaker {
+	c.cooldown = d
+	return c
+}
+
+// OnStateChange sets a function called on every state transition.
+// It is called synchronously and must not block. It can call [circuitBreaker.State].
+func (c *circuitBreaker) OnStateChange(fn func(from, to CircuitState)) *circuitBreaker {
+	c.onStateChange = fn
+	return c
+}
+
+// Clock sets the clock used for the cooldown. Defaults to the system clock.
+func (c *circuitBreaker) Clock(clock Clock) *circuitBreaker {
+	c.clock = clock
+	return c
+}
+
+// State returns the current state of the circuit. It is safe for concurrent use.
+func (c *circuitBreaker) State() CircuitState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.state
+}
+
+func (c *circuitBreaker) Run(ctx context.Context) error {
+	c.mu.Lock()
+	state, reopenAt := c.state, c.openedAt.Add(c.cooldown)
+	c.mu.Unlock()
+
+	if state == CircuitOpen {
+		cooldown, stop := c.clock.NewTimer(reopenAt.Sub(c.clock.Now()))
+		select {
+		case <-ctx.Done():
+			stop()
+			return ctx.Err()
+		case <-cooldown:
+		}
+		c.mu.Lock()
+		notify := c.setState(CircuitHalfOpen)
+		c.mu.Unlock()
+		notify()
+	}
+
+	err := c.runnable.Run(ctx)
+	if ctx.Err() != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	notify := c.completed(err != nil)
+	c.mu.Unlock()
+	notify()
+
+	return err
+}
+
+// completed records the result of a run, and transitions the circuit accordingly.
+// Must be called with the lock held. See setState for the returned function.
+func (c *circuitBreaker) completed(failed bool) func() {
+	c.record(failed)
+
+	switch {
+	case !failed && c.state == CircuitHalfOpen:
+		c.reset()
+		return c.setState(CircuitClosed)
+	case failed && (c.state == CircuitHalfOpen || c.tripped()):
+		c.reset()
+		c.openedAt = c.clock.Now()
+		return c.setState(CircuitOpen)
+	default:
+		return func() {}
+	}
+}
+
+// record tracks the result of a run. Must be called with the lock held.
+func (c *circuitBreaker) record(failed bool) {
+	if failed {
+		c.consecutive++
+	} else {
+		c.consecutive = 0
+	}
+
+	if c.ratioWindow > 0 {
+		c.results = append(c.results, failed)
+		if len(c.results) > c.ratioWindow {
+			c.results = c.results[1:]
+		}
+	}
+}
+
+// tripped reports whether the failures warrant opening the circuit. Must be called with the lock held.
+func (c *circuitBreaker) tripped() bool {
+	if c.failureThreshold > 0 && c.consecutive >= c.failureThreshold {
+		return true
+	}
+
+	if c.ratioWindow > 0 && len(c.results) == c.ratioWindow {
+		failures := 0
+		for _, failed := range c.results {
+			if failed {
+				failures++
+			}
+		}
+		return float64(failures)/float64(c.ratioWindow) >= c.failureRatio
+	}
+
+	return false
+}
+
+// reset clears the failure counters. Must be called with the lock held.
+func (c *circuitBreaker) reset() {
+	c.consecutive = 0
+	c.results = nil
+}
+
+// setState transitions the circuit. Must be called with the lock held. It returns a
+// function reporting the transition, to call once the lock is released.
+func (c *circuitBreaker) setState(to CircuitState) func() {
+	from := c.state
+	if from == to {
+		return func() {}
+	}
+	c.state = to
+
+	return func() {
+		logger.Info(c.name+": circuit "+to.String(), "from", from.String())
+		if c.onStateChange != nil {
+			c.onStateChange(from, to)
+		}
+	}
+}
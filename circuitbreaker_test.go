@@ -0,0 +1,118 @@
+package runnable
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Run("cancellation", func(t *testing.T) {
+		cb := CircuitBreaker(newDummyRunnable())
+		AssertRunnableRespectCancellation(t, cb, time.Millisecond*100)
+		AssertRunnableRespectPreCancelledContext(t, cb)
+	})
+
+	t.Run("opens after consecutive failures", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			dying := newDyingRunnable()
+
+			var transitions []string
+			cb := CircuitBreaker(dying).
+				FailureThreshold(3).
+				Cooldown(time.Minute).
+				OnStateChange(func(from, to CircuitState) {
+					transitions = append(transitions, from.String()+"->"+to.String())
+				})
+
+			for range 3 {
+				require.EqualError(t, cb.Run(context.Background()), "dying")
+			}
+			require.Equal(t, CircuitOpen, cb.State())
+			require.Equal(t, 3, dying.counter)
+
+			// While open, the inner runnable is not invoked until the cooldown elapsed.
+			start := time.Now()
+			require.EqualError(t, cb.Run(context.Background()), "dying")
+			require.Equal(t, time.Minute, time.Since(start))
+			require.Equal(t, 4, dying.counter)
+
+			// The trial run failed: the circuit is open again.
+			require.Equal(t, CircuitOpen, cb.State())
+			require.Equal(t, []string{"closed->open", "open->half-open", "half-open->open"}, transitions)
+		})
+	})
+
+	t.Run("successful trial closes the circuit", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			failing := true
+			fn := Func(func(ctx context.Context) error {
+				if failing {
+					return errors.New("broker down")
+				}
+				return nil
+			})
+
+			cb := CircuitBreaker(fn).FailureThreshold(1).Cooldown(time.Minute)
+
+			require.Error(t, cb.Run(context.Background()))
+			require.Equal(t, CircuitOpen, cb.State())
+
+			failing = false
+			require.NoError(t, cb.Run(context.Background()))
+			require.Equal(t, CircuitClosed, cb.State())
+		})
+	})
+
+	t.Run("state from the callback", func(t *testing.T) {
+		var states []CircuitState
+		var cb *circuitBreaker
+		cb = CircuitBreaker(newDyingRunnable()).
+			FailureThreshold(1).
+			OnStateChange(func(_, to CircuitState) {
+				states = append(states, cb.State())
+			})
+
+		require.EqualError(t, cb.Run(context.Background()), "dying")
+		require.Equal(t, []CircuitState{CircuitOpen}, states)
+	})
+
+	t.Run("failure ratio", func(t *testing.T) {
+		callCount := 0
+		fn := Func(func(ctx context.Context) error {
+			callCount++
+			if callCount%2 == 0 {
+				return errors.New("flaky")
+			}
+			return nil
+		})
+
+		cb := CircuitBreaker(fn).FailureThreshold(0).FailureRatio(0.5, 4)
+
+		for range 3 {
+			_ = cb.Run(context.Background())
+			require.Equal(t, CircuitClosed, cb.State())
+		}
+
+		_ = cb.Run(context.Background()) // 2 failures out of 4
+		require.Equal(t, CircuitOpen, cb.State())
+	})
+
+	t.Run("waiting while open respects cancellation", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			cb := CircuitBreaker(newDyingRunnable()).FailureThreshold(1).Cooldown(time.Hour)
+			require.Error(t, cb.Run(context.Background()))
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			err := cb.Run(ctx)
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+			require.Equal(t, CircuitOpen, cb.State())
+		})
+	})
+}
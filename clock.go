@@ -0,0 +1,50 @@
+package runnable
+
+import (
+	"context"
+	"time"
+)
+
+// Clock is the source of time of the time-based wrappers ([Schedule], [Restart],
+// [CircuitBreaker], [Timeout], [CancelWatch] and [Manager]), of the pre-stop delay of
+// [Signal], and of [FileElector]. The default is the system clock. Tests can inject
+// a manual clock, like the one of the runnabletest package, to control time without
+// sleeping.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTimer starts a timer, returning a channel receiving the current time once the
+	// duration elapsed, and a function stopping the timer. Stop reports whether the
+	// timer was stopped before it fired, like [time.Timer.Stop].
+	NewTimer(d time.Duration) (c <-chan time.Time, stop func() bool)
+}
+
+// systemClock is the default [Clock], backed by the time package.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) NewTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	t := time.NewTimer(d)
+	return t.C, t.Stop
+}
+
+// withTimeout is like [context.WithTimeout], with the deadline measured by the clock.
+// When the timeout expires, the cause of the context is [context.DeadlineExceeded].
+func withTimeout(ctx context.Context, clock Clock, d time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := clock.(systemClock); ok {
+		return context.WithTimeout(ctx, d)
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	expired, stop := clock.NewTimer(d)
+	go func() {
+		defer stop()
+		select {
+		case <-expired:
+			cancel(context.DeadlineExceeded)
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, func() { cancel(nil) }
+}
@@ -0,0 +1,254 @@
+package runnable
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron returns a schedule spec from a cron expression, evaluated in the location
+// of the current time.
+//
+// The standard five fields are supported (minute, hour, day of month, month, day of
+// week), with an optional leading seconds field. Each field accepts *, values,
+// ranges (1-5), steps (*/15, 10-40/5) and lists (1,15,30). Months and days of the
+// week accept names (JAN-DEC, SUN-SAT), and 7 is also Sunday. When both the day of
+// month and the day of week are restricted, the spec fires when either matches.
+//
+// The descriptors @yearly (or @annually), @monthly, @weekly, @daily (or @midnight)
+// and @hourly are also supported.
+//
+// Like [DailyAt], a wall clock time skipped by a DST transition fires at the first
+// valid instant after it, and a repeated wall clock time fires once.
+// An expression that never matches, like "0 0 30 2 *", never fires.
+func Cron(expr string) (ScheduleSpec, error) {
+	c, err := parseCron(expr)
+	if err != nil {
+		return nil, err
+	}
+	return func(_, now time.Time) time.Time {
+		return c.next(now)
+	}, nil
+}
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+var cronMonths = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var cronWeekdays = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// cronField describes the accepted values of a cron field.
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronSecond  = cronField{"second", 0, 59, nil}
+	cronMinute  = cronField{"minute", 0, 59, nil}
+	cronHour    = cronField{"hour", 0, 23, nil}
+	cronDom     = cronField{"day of month", 1, 31, nil}
+	cronMonth   = cronField{"month", 1, 12, cronMonths}
+	cronWeekday = cronField{"day of week", 0, 7, cronWeekdays}
+)
+
+// cronSchedule holds the matching values of each field as bitsets.
+type cronSchedule struct {
+	second, minute, hour, dom, month, dow uint64
+	domStar, dowStar                      bool
+}
+
+func parseCron(expr string) (*cronSchedule, error) {
+	spec := strings.TrimSpace(expr)
+	if strings.HasPrefix(spec, "@") {
+		var ok bool
+		spec, ok = cronDescriptors[strings.ToLower(spec)]
+		if !ok {
+			return nil, fmt.Errorf("cron %q: unknown descriptor", expr)
+		}
+	}
+
+	fields := strings.Fields(spec)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("cron %q: expected 5 or 6 fields, got %d", expr, len(fields))
+	}
+
+	c := &cronSchedule{}
+	var err error
+	for i, target := range []struct {
+		bits  *uint64
+		field cronField
+	}{
+		{&c.second, cronSecond},
+		{&c.minute, cronMinute},
+		{&c.hour, cronHour},
+		{&c.dom, cronDom},
+		{&c.month, cronMonth},
+		{&c.dow, cronWeekday},
+	} {
+		*target.bits, err = target.field.parse(fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("cron %q: %w", expr, err)
+		}
+	}
+
+	// Sunday is both 0 and 7.
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	c.domStar = strings.HasPrefix(fields[3], "*") || fields[3] == "?"
+	c.dowStar = strings.HasPrefix(fields[5], "*") || fields[5] == "?"
+
+	return c, nil
+}
+
+// parse returns the bitset of the values matched by a comma-separated list of terms.
+func (f cronField) parse(text string) (uint64, error) {
+	var bits uint64
+	for term := range strings.SplitSeq(text, ",") {
+		b, err := f.parseTerm(term)
+		if err != nil {
+			return 0, err
+		}
+		bits |= b
+	}
+	return bits, nil
+}
+
+// parseTerm parses a single term: *, ?, a value, or a range, with an optional step.
+func (f cronField) parseTerm(term string) (uint64, error) {
+	rangePart, stepPart, hasStep := strings.Cut(term, "/")
+
+	start, end := f.min, f.max
+	switch {
+	case rangePart == "*" || rangePart == "?":
+	case strings.Contains(rangePart, "-"):
+		lo, hi, _ := strings.Cut(rangePart, "-")
+		var err error
+		if start, err = f.value(lo); err != nil {
+			return 0, err
+		}
+		if end, err = f.value(hi); err != nil {
+			return 0, err
+		}
+		if start > end {
+			return 0, fmt.Errorf("%s: invalid range %q", f.name, rangePart)
+		}
+	default:
+		var err error
+		if start, err = f.value(rangePart); err != nil {
+			return 0, err
+		}
+		if !hasStep {
+			end = start
+		}
+	}
+
+	step := 1
+	if hasStep {
+		var err error
+		step, err = strconv.Atoi(stepPart)
+		if err != nil || step <= 0 {
+			return 0, fmt.Errorf("%s: invalid step %q", f.name, stepPart)
+		}
+	}
+
+	var bits uint64
+	for v := start; v <= end; v += step {
+		bits |= 1 << v
+	}
+	return bits, nil
+}
+
+// value parses a single number or name, and checks its bounds.
+func (f cronField) value(text string) (int, error) {
+	if v, ok := f.names[strings.ToLower(text)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid value %q", f.name, text)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("%s: value %d out of range [%d-%d]", f.name, v, f.min, f.max)
+	}
+	return v, nil
+}
+
+// cronSearchLimit bounds the search for the next time, in days, for expressions
+// that never match (like February 30th).
+const cronSearchLimit = 5 * 366
+
+// next returns the first time strictly after now that matches the schedule,
+// or the zero time if there is none within the next years. The search walks the
+// wall clock, so that DST transitions behave like the other clock-aligned specs.
+func (c *cronSchedule) next(now time.Time) time.Time {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	for range cronSearchLimit {
+		if c.month&(1<<uint(day.Month())) != 0 && c.matchDay(day) {
+			if t := c.nextInDay(day, now); !t.IsZero() {
+				return t
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return time.Time{}
+}
+
+// nextInDay returns the first time of the given calendar day that matches the schedule
+// and is after now, or the zero time if there is none.
+func (c *cronSchedule) nextInDay(day, now time.Time) time.Time {
+	year, month, dom := day.Date()
+	loc := now.Location()
+
+	for hour := range 24 {
+		if c.hour&(1<<uint(hour)) == 0 || !wallTime(year, month, dom, hour, 59, 59, loc).After(now) {
+			continue
+		}
+		for minute := range 60 {
+			if c.minute&(1<<uint(minute)) == 0 || !wallTime(year, month, dom, hour, minute, 59, loc).After(now) {
+				continue
+			}
+			for second := range 60 {
+				if c.second&(1<<uint(second)) == 0 {
+					continue
+				}
+				if t := wallTime(year, month, dom, hour, minute, second, loc); t.After(now) {
+					return t
+				}
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// matchDay reports whether the day of t matches, following the cron rule: when both
+// the day of month and the day of week are restricted, either one can match.
+func (c *cronSchedule) matchDay(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,82 @@
+package runnable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCron(t *testing.T) {
+	// 2025-01-01 is a Wednesday.
+	now := time.Date(2025, 1, 1, 14, 20, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2025, 1, 1, 14, 21, 0, 0, time.UTC)},
+		{"15 */6 * * *", time.Date(2025, 1, 1, 18, 15, 0, 0, time.UTC)},
+		{"15 14 * * *", time.Date(2025, 1, 2, 14, 15, 0, 0, time.UTC)},
+		{"0,20,40 * * * *", time.Date(2025, 1, 1, 14, 40, 0, 0, time.UTC)},
+		{"10-40/15 * * * *", time.Date(2025, 1, 1, 14, 25, 0, 0, time.UTC)},
+		{"0 9 * * MON-FRI", time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * sat,sun", time.Date(2025, 1, 4, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 7", time.Date(2025, 1, 5, 9, 0, 0, 0, time.UTC)},
+		{"0 0 1 mar *", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 13 * FRI", time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)}, // day of month OR day of week
+		{"*/10 * * * * *", time.Date(2025, 1, 1, 14, 20, 40, 0, time.UTC)},
+		{"45 20 14 * * *", time.Date(2025, 1, 1, 14, 20, 45, 0, time.UTC)},
+		{"@hourly", time.Date(2025, 1, 1, 15, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"@midnight", time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}}, // never
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			spec, err := Cron(tt.expr)
+			require.NoError(t, err)
+			require.Equal(t, tt.want.String(), spec(time.Time{}, now).String())
+		})
+	}
+}
+
+func TestCron_ExactMatchIsExcluded(t *testing.T) {
+	spec, err := Cron("30 14 * * *")
+	require.NoError(t, err)
+
+	now := time.Date(2025, 1, 1, 14, 30, 0, 0, time.UTC)
+	want := time.Date(2025, 1, 2, 14, 30, 0, 0, time.UTC)
+
+	require.Equal(t, want.String(), spec(time.Time{}, now).String())
+}
+
+func TestCron_Errors(t *testing.T) {
+	tests := []struct {
+		expr string
+		err  string
+	}{
+		{"", `cron "": expected 5 or 6 fields, got 0`},
+		{"* * * *", `cron "* * * *": expected 5 or 6 fields, got 4`},
+		{"@often", `cron "@often": unknown descriptor`},
+		{"60 * * * *", `cron "60 * * * *": minute: value 60 out of range [0-59]`},
+		{"* 24 * * *", `cron "* 24 * * *": hour: value 24 out of range [0-23]`},
+		{"* * 0 * *", `cron "* * 0 * *": day of month: value 0 out of range [1-31]`},
+		{"* * * foo *", `cron "* * * foo *": month: invalid value "foo"`},
+		{"* * * * 5-1", `cron "* * * * 5-1": day of week: invalid range "5-1"`},
+		{"*/0 * * * *", `cron "*/0 * * * *": minute: invalid step "0"`},
+		{"1,,2 * * * *", `cron "1,,2 * * * *": minute: invalid value ""`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Cron(tt.expr)
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
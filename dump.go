@@ -0,0 +1,81 @@
+package runnable
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"runtime/pprof"
+	"strings"
+)
+
+// runnableLabel is the pprof label set on the goroutines of the runnables started by
+// a [Manager], with the name of the runnable.
+const runnableLabel = "runnable"
+
+// writeDump writes the state of the runnable tree, and the stacks of all goroutines.
+func writeDump(w io.Writer, runnable Runnable) error {
+	var buf bytes.Buffer
+
+	buf.WriteString("runnables:\n")
+	if m := asManager(runnable); m != nil {
+		m.writeState(&buf, "  ")
+	} else {
+		buf.WriteString("  " + runnableName(runnable) + "\n")
+	}
+
+	buf.WriteString("\ngoroutines:\n")
+	if err := writeGoroutines(&buf, ""); err != nil {
+		return err
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// writeGoroutines writes the stacks of the goroutines, with their pprof labels. When
+// name is not empty, only the goroutines of the runnable with that name are written.
+func writeGoroutines(w io.Writer, name string) error {
+	var buf bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, 1); err != nil {
+		return err
+	}
+	if name == "" {
+		_, err := w.Write(buf.Bytes())
+		return err
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "goroutines of %s:\n", name)
+
+	// The profile lists a record per distinct stack, separated by blank lines.
+	label := fmt.Sprintf("%q:%q", runnableLabel, name)
+	found := false
+	for record := range strings.SplitSeq(buf.String(), "\n\n") {
+		_, labels, _ := strings.Cut(record, "# labels: ")
+		labels, _, _ = strings.Cut(labels, "\n")
+		if strings.Contains(labels, label) {
+			out.WriteString(record + "\n\n")
+			found = true
+		}
+	}
+	if !found {
+		out.WriteString("none\n\n")
+	}
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// asManager returns the manager wrapped by the runnable, or nil.
+func asManager(runnable Runnable) *manager {
+	for {
+		if m, ok := runnable.(*manager); ok {
+			return m
+		}
+		u, ok := runnable.(unwrapper)
+		if !ok {
+			return nil
+		}
+		runnable = u.unwrap()
+	}
+}
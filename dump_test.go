@@ -0,0 +1,100 @@
+package runnable
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// lockedBuffer is a buffer safe for concurrent use.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func wedgedRunnable(unblock <-chan struct{}) *funcRunnable {
+	return Func(func(ctx context.Context) error {
+		<-unblock
+		return nil
+	}).Name("wedged")
+}
+
+func TestManager_StuckDump(t *testing.T) {
+	unblock := make(chan struct{})
+	defer close(unblock)
+
+	var dump bytes.Buffer
+	m := Manager().ShutdownTimeout(10 * time.Millisecond).StuckDump(&dump)
+	m.Register(wedgedRunnable(unblock), newDummyRunnable())
+
+	err := m.Run(cancelledContext())
+	require.EqualError(t, err, "manager: wedged is still running")
+
+	out := dump.String()
+	require.True(t, strings.HasPrefix(out, "goroutines of manager/wedged:\n"), out)
+	require.Contains(t, out, `# labels: {"runnable":"manager/wedged"}`)
+	require.Contains(t, out, "wedgedRunnable.func1")
+	require.NotContains(t, out, "dummyRunnable")
+	require.NotContains(t, out, "TestManager_StuckDump")
+}
+
+func TestWriteDump(t *testing.T) {
+	unblock := make(chan struct{})
+	defer close(unblock)
+
+	sub := Manager().Name("sub")
+	sub.Register(Restart(wedgedRunnable(unblock)))
+
+	m := Manager().ShutdownTimeout(10 * time.Millisecond)
+	m.Register(sub)
+	m.RegisterService(newDummyRunnable())
+
+	var dump bytes.Buffer
+	require.NoError(t, writeDump(&dump, Signal(m)))
+
+	state, _, _ := strings.Cut(dump.String(), "goroutines:")
+	require.Equal(t, `runnables:
+  manager: not running
+    service dummyRunnable: not started
+    process sub: not started
+      sub: not running
+        process restart/wedged: not started
+
+`, state)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- m.Run(ctx) }()
+
+	require.Eventually(t, func() bool {
+		dump.Reset()
+		require.NoError(t, writeDump(&dump, m))
+		return strings.Contains(dump.String(), "process restart/wedged: running for")
+	}, time.Second, 10*time.Millisecond)
+
+	out := dump.String()
+	require.Contains(t, out, "  manager: running\n")
+	require.Contains(t, out, "    service dummyRunnable: running for ")
+	require.Contains(t, out, "      sub: running\n")
+	require.Contains(t, out, `# labels: {"runnable":"sub/restart/wedged"}`)
+
+	cancel()
+	<-done
+}
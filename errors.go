@@ -1,6 +1,13 @@
 package runnable
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+)
 
 type RunnableError struct {
 	msg string
@@ -14,3 +21,147 @@ func (e *RunnableError) Error() string {
 func (e *RunnableError) Unwrap() error {
 	return e.err
 }
+
+// PermanentError marks an error that retrying cannot fix. See [Permanent].
+type PermanentError struct {
+	err error
+}
+
+// Permanent marks err as permanent: [Restart] returns it immediately instead of
+// restarting the runnable. Returns nil if err is nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{err}
+}
+
+func (e *PermanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.err
+}
+
+// TransientError marks an expected, temporary error. See [Transient].
+type TransientError struct {
+	err error
+}
+
+// Transient marks err as transient: [Restart] restarts the runnable without counting
+// the error toward [restart.ErrorLimit]. Returns nil if err is nil.
+func Transient(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &TransientError{err}
+}
+
+func (e *TransientError) Error() string {
+	return e.err.Error()
+}
+
+func (e *TransientError) Unwrap() error {
+	return e.err
+}
+
+// TimeoutError is returned when a runnable did not complete within its allotted time.
+// It wraps [context.DeadlineExceeded].
+type TimeoutError struct {
+	Name    string
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s: timed out after %s", e.Name, e.Timeout)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// ErrShutdownForced is returned by [HTTPServer] when its graceful shutdown was forced
+// by a repeated signal (see [signal.Escalate]), closing the connections of the
+// requests still in flight.
+var ErrShutdownForced = errors.New("shutdown forced")
+
+// The following errors are the causes of the context cancellations, available with
+// [context.Cause], so that runnables can tell why they are stopping. Except for
+// [ShutdownTimeoutError], they wrap [context.Canceled].
+
+// ErrParentShutdown is the cause of the cancellation of the runnables of a [Manager]
+// when its context was cancelled without a more specific cause.
+var ErrParentShutdown = fmt.Errorf("parent shutdown: %w", context.Canceled)
+
+// SignalError is the cause of the cancellation by [Signal].
+type SignalError struct {
+	Signal os.Signal
+}
+
+func (e *SignalError) Error() string {
+	return fmt.Sprintf("received signal %s", e.Signal)
+}
+
+func (e *SignalError) Unwrap() error {
+	return context.Canceled
+}
+
+// ExitCode returns 128 + the signal number, the conventional exit code of a process
+// terminated by a signal. See [Main].
+func (e *SignalError) ExitCode() int {
+	if sig, ok := e.Signal.(syscall.Signal); ok {
+		return 128 + int(sig)
+	}
+	return 1
+}
+
+// RunnableDiedError is the cause of the cancellation of the runnables of a [Manager]
+// when one of them stopped, with Err the error it returned, if any.
+type RunnableDiedError struct {
+	Name string
+	Err  error
+}
+
+func (e *RunnableDiedError) Error() string {
+	if e.Err == nil {
+		return e.Name + " stopped"
+	}
+	return fmt.Sprintf("%s died: %s", e.Name, e.Err)
+}
+
+func (e *RunnableDiedError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{context.Canceled}
+	}
+	return []error{context.Canceled, e.Err}
+}
+
+// RestartRequestedError is the cause of the cancellation by [restart.RestartNow] and
+// [manager.RestartNow].
+type RestartRequestedError struct {
+	Reason string
+}
+
+func (e *RestartRequestedError) Error() string {
+	return "restart requested: " + e.Reason
+}
+
+func (e *RestartRequestedError) Unwrap() error {
+	return context.Canceled
+}
+
+// ShutdownTimeoutError is the cause of the cancellation of a graceful shutdown that
+// exceeded its timeout, like the one of [HTTPServer]. It wraps [context.DeadlineExceeded].
+type ShutdownTimeoutError struct {
+	Name    string
+	Timeout time.Duration
+}
+
+func (e *ShutdownTimeoutError) Error() string {
+	return fmt.Sprintf("%s: shutdown timed out after %s", e.Name, e.Timeout)
+}
+
+func (e *ShutdownTimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
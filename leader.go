@@ -0,0 +1,202 @@
+package runnable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrLeadershipLost is the cause of the context cancellation of a runnable managed by
+// [Leader] when the leadership is lost.
+var ErrLeadershipLost = errors.New("leadership lost")
+
+// Elector elects a single leader among replicas, for [Leader]. Implementations can
+// rely on Kubernetes leases, etcd, Consul, etc.
+type Elector interface {
+	// Campaign blocks until the leadership is acquired, or the context is cancelled.
+	// It returns a channel closed when the leadership is lost.
+	Campaign(ctx context.Context) (<-chan struct{}, error)
+	// Resign gives up the leadership, if held.
+	Resign(ctx context.Context) error
+}
+
+// Leader returns a runnable that runs the given runnable only while holding the
+// leadership, for singleton workers.
+//
+// Leader campaigns through the elector, and starts the runnable once elected. When the
+// leadership is lost, the runnable is cancelled with the cause [ErrLeadershipLost], and
+// Leader campaigns again once it returned. When the runnable returns by itself, Leader
+// resigns and returns its error. Errors from the elector campaign are returned.
+// Context cancellation stops the runnable, resigns, and returns [context.Canceled].
+func Leader(elector Elector, runnable Runnable) *leader {
+	return &leader{
+		name:     "leader/" + runnableName(runnable),
+		elector:  elector,
+		runnable: runnable,
+	}
+}
+
+type leader struct {
+	name     string
+	elector  Elector
+	runnable Runnable
+}
+
+var _ Runnable = (*leader)(nil)
+
+func (l *leader) runnableName() string { return l.name }
+func (l *leader) unwrap() Runnable     { return l.runnable }
+
+func (l *leader) Run(ctx context.Context) error {
+	for {
+		logger.Info(l.name + ": campaigning")
+
+		lost, err := l.elector.Campaign(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			return fmt.Errorf("%s: campaign: %w", l.name, err)
+		}
+
+		logger.Info(l.name + ": elected")
+
+		lostLeadership, err := l.runElected(ctx, lost)
+
+		// Resign during shutdown too.
+		if resignErr := l.elector.Resign(context.WithoutCancel(ctx)); resignErr != nil {
+			logger.Info(l.name+": failed to resign", "error", resignErr)
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !lostLeadership {
+			return err
+		}
+		logger.Info(l.name+": leadership lost", "error", err)
+	}
+}
+
+// runElected runs the runnable until it returns, cancelling it when the leadership is
+// lost. It reports whether the leadership was lost.
+func (l *leader) runElected(ctx context.Context, lost <-chan struct{}) (bool, error) {
+	runCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	go func() {
+		select {
+		case <-lost:
+			cancel(ErrLeadershipLost)
+		case <-runCtx.Done():
+		}
+	}()
+
+	err := l.runnable.Run(runCtx)
+	cancel(nil)
+
+	return errors.Is(context.Cause(runCtx), ErrLeadershipLost), err
+}
+
+// FileElector returns an [Elector] using a lock file named after the key in the given
+// directory, which must exist, for local use and tests. Each call returns a distinct
+// candidate. The lock expires after the ttl, and is renewed by the leader every third
+// of the ttl. Candidates retry at the same interval.
+func FileElector(dir, key string, ttl time.Duration) *fileElector {
+	return &fileElector{
+		locker: FileLocker(dir).(*fileLocker),
+		key:    key,
+		ttl:    ttl,
+		clock:  systemClock{},
+	}
+}
+
+type fileElector struct {
+	locker *fileLocker
+	key    string
+	ttl    time.Duration
+	clock  Clock
+
+	mu      sync.Mutex
+	stop    context.CancelFunc // stops renewing the lock
+	renewed chan struct{}      // closed when renewing stopped
+}
+
+var _ Elector = (*fileElector)(nil)
+
+// Clock sets the clock used for the expiry of the lock, and the intervals of the
+// renewals and retries. Defaults to the system clock.
+func (e *fileElector) Clock(clock Clock) *fileElector {
+	e.clock = clock
+	e.locker.clock = clock
+	return e
+}
+
+func (e *fileElector) Campaign(ctx context.Context) (<-chan struct{}, error) {
+	interval := e.ttl / 3
+
+	for {
+		locked, err := e.locker.TryLock(ctx, e.key, e.ttl)
+		if err != nil {
+			return nil, err
+		}
+		if locked {
+			break
+		}
+
+		retry, stop := e.clock.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			stop()
+			return nil, ctx.Err()
+		case <-retry:
+		}
+	}
+
+	renewCtx, stop := context.WithCancel(context.Background())
+	lost := make(chan struct{})
+
+	e.mu.Lock()
+	e.stop, e.renewed = stop, lost
+	e.mu.Unlock()
+
+	go func() {
+		defer close(lost)
+		for {
+			renew, stop := e.clock.NewTimer(interval)
+			select {
+			case <-renewCtx.Done():
+				stop()
+				return
+			case <-renew:
+			}
+
+			renewed, err := e.locker.refresh(e.key, e.ttl)
+			if err != nil {
+				logger.Info("file elector: failed to renew the lock", "key", e.key, "error", err)
+			}
+			if !renewed {
+				return
+			}
+		}
+	}()
+
+	return lost, nil
+}
+
+func (e *fileElector) Resign(ctx context.Context) error {
+	e.mu.Lock()
+	stop, renewed := e.stop, e.renewed
+	e.stop, e.renewed = nil, nil
+	e.mu.Unlock()
+
+	if stop == nil {
+		return nil
+	}
+	stop()
+	<-renewed
+
+	return e.locker.Unlock(ctx, e.key)
+}
@@ -0,0 +1,163 @@
+package runnable
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/pior/runnable/runnabletest"
+)
+
+// testElector is an Elector controlled by the test.
+type testElector struct {
+	grants  chan chan struct{} // leadership granted, closing the channel revokes it
+	resigns int
+}
+
+func (e *testElector) Campaign(ctx context.Context) (<-chan struct{}, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case lost := <-e.grants:
+		return lost, nil
+	}
+}
+
+func (e *testElector) Resign(context.Context) error {
+	e.resigns++
+	return nil
+}
+
+func TestLeader(t *testing.T) {
+	t.Run("cancellation", func(t *testing.T) {
+		elector := &testElector{grants: make(chan chan struct{}, 1)}
+		elector.grants <- make(chan struct{})
+
+		l := Leader(elector, newDummyRunnable())
+		AssertRunnableRespectCancellation(t, l, time.Millisecond*100)
+		AssertRunnableRespectPreCancelledContext(t, l)
+	})
+
+	t.Run("leadership lost", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			elector := &testElector{grants: make(chan chan struct{})}
+
+			var runs int
+			var cause error
+			worker := Func(func(ctx context.Context) error {
+				runs++
+				if runs == 2 {
+					return errors.New("done")
+				}
+				<-ctx.Done()
+				cause = context.Cause(ctx)
+				return ctx.Err()
+			})
+
+			done := make(chan error)
+			go func() { done <- Leader(elector, worker).Run(context.Background()) }()
+
+			lost := make(chan struct{})
+			elector.grants <- lost
+			close(lost)
+
+			// Campaigns again once the runnable returned.
+			elector.grants <- make(chan struct{})
+
+			require.EqualError(t, <-done, "done")
+			require.Equal(t, 2, runs)
+			require.Equal(t, ErrLeadershipLost, cause)
+			require.Equal(t, 2, elector.resigns)
+		})
+	})
+
+	t.Run("runnable returns", func(t *testing.T) {
+		elector := &testElector{grants: make(chan chan struct{}, 1)}
+		elector.grants <- make(chan struct{})
+
+		counter := newCounterRunnable()
+		require.NoError(t, Leader(elector, counter).Run(context.Background()))
+		require.Equal(t, 1, counter.counter)
+		require.Equal(t, 1, elector.resigns)
+	})
+
+	t.Run("campaign error", func(t *testing.T) {
+		elector := FileElector("/does/not/exist", "job", time.Minute)
+
+		err := Leader(elector, newCounterRunnable()).Run(context.Background())
+		require.ErrorContains(t, err, "leader/counter: campaign: file locker:")
+	})
+}
+
+func TestLeader_FileElector(t *testing.T) {
+	dir := t.TempDir()
+
+	synctest.Test(t, func(t *testing.T) {
+		elected := make([]time.Time, 2)
+		replica := func(i int) Runnable {
+			worker := Func(func(ctx context.Context) error {
+				elected[i] = time.Now()
+				<-ctx.Done()
+				return ctx.Err()
+			})
+			return Leader(FileElector(dir, "job", 30*time.Second), worker)
+		}
+
+		start := time.Now()
+
+		ctx1, cancel1 := context.WithTimeout(context.Background(), 65*time.Second)
+		defer cancel1()
+		done1 := make(chan error)
+		go func() { done1 <- replica(0).Run(ctx1) }()
+		synctest.Wait()
+
+		ctx2, cancel2 := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel2()
+		done2 := make(chan error)
+		go func() { done2 <- replica(1).Run(ctx2) }()
+
+		// The first replica renews its lock beyond the ttl, until it stops.
+		require.ErrorIs(t, <-done1, context.DeadlineExceeded)
+		require.Equal(t, start, elected[0])
+
+		// The second replica is elected on its next attempt.
+		require.ErrorIs(t, <-done2, context.DeadlineExceeded)
+		require.Equal(t, start.Add(70*time.Second), elected[1])
+	})
+}
+
+func TestLeader_FileElector_Clock(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	clock := runnabletest.NewClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	leader := FileElector(dir, "job", 30*time.Second).Clock(clock)
+	candidate := FileElector(dir, "job", 30*time.Second).Clock(clock)
+
+	lost, err := leader.Campaign(ctx)
+	require.NoError(t, err)
+
+	elected := make(chan error)
+	go func() {
+		_, err := candidate.Campaign(ctx)
+		elected <- err
+	}()
+
+	// The leader waits to renew its lock, the candidate waits to retry.
+	clock.BlockUntil(2)
+	require.Equal(t, []time.Time{
+		clock.Now().Add(10 * time.Second), clock.Now().Add(10 * time.Second),
+	}, clock.Timers())
+
+	// The leader missed its renewals: the lock expired.
+	clock.Advance(40 * time.Second)
+	<-lost
+	require.NoError(t, <-elected)
+
+	require.NoError(t, leader.Resign(ctx))
+	require.NoError(t, candidate.Resign(ctx))
+}
@@ -0,0 +1,165 @@
+package runnable
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrLockHeld is reported in the [ScheduleSkipped] event of a tick skipped because the
+// lock is held elsewhere. See [schedule.Locker].
+var ErrLockHeld = errors.New("lock held elsewhere")
+
+// Locker is a distributed lock, used by [schedule.Locker] so that a single replica
+// runs each tick. Implementations can rely on Redis, Postgres advisory locks, etc.
+type Locker interface {
+	// TryLock acquires the lock for the key, until released or until the ttl expired.
+	// It returns false without error when the lock is held, including by this locker.
+	TryLock(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	// Unlock releases the lock for the key, if still held by this locker. Schedules
+	// call it once the lock expired, to clean up the locks of the previous ticks.
+	Unlock(ctx context.Context, key string) error
+}
+
+// FileLocker returns a [Locker] using lock files in the given directory, which must
+// exist, for local use and tests. Each call returns a distinct owner: two lockers on the same directory
+// exclude each other, like two replicas would.
+//
+// An expired lock is taken over by removing its file, which is not atomic: two
+// lockers taking over the same expired lock at the same time can both acquire it.
+func FileLocker(dir string) Locker {
+	owner := make([]byte, 8)
+	_, _ = rand.Read(owner)
+	return &fileLocker{dir: dir, owner: hex.EncodeToString(owner), clock: systemClock{}}
+}
+
+type fileLocker struct {
+	dir   string
+	owner string
+	clock Clock
+}
+
+// fileLock is the content of a lock file.
+type fileLock struct {
+	Owner   string    `json:"owner"`
+	Expires time.Time `json:"expires"`
+}
+
+func (l *fileLocker) path(key string) string {
+	return filepath.Join(l.dir, url.PathEscape(key)+".lock")
+}
+
+func (l *fileLocker) TryLock(_ context.Context, key string, ttl time.Duration) (bool, error) {
+	data, err := json.Marshal(fileLock{Owner: l.owner, Expires: l.clock.Now().Add(ttl)})
+	if err != nil {
+		return false, fmt.Errorf("file locker: %w", err)
+	}
+
+	// Write the lock to a temporary file first, then link it: the lock file is created
+	// atomically with its content, and only if it does not exist.
+	var locked bool
+	err = writeTempFile(l.dir, ".lock-*", data, func(tmp string) (err error) {
+		locked, err = l.link(tmp, l.path(key))
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("file locker: %w", err)
+	}
+	return locked, nil
+}
+
+// link links the temporary file to the lock file, if there is no lock or if it expired.
+func (l *fileLocker) link(tmp, path string) (bool, error) {
+	for range 2 {
+		err := os.Link(tmp, path)
+		if err == nil {
+			return true, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return false, err
+		}
+
+		lock, found, err := l.read(path)
+		if err != nil {
+			return false, err
+		}
+		if found && l.clock.Now().Before(lock.Expires) {
+			return false, nil
+		}
+
+		// Expired, or released in the meantime: take over once.
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+func (l *fileLocker) Unlock(_ context.Context, key string) error {
+	path := l.path(key)
+
+	lock, found, err := l.read(path)
+	if err != nil {
+		return fmt.Errorf("file locker: %w", err)
+	}
+	if !found || lock.Owner != l.owner {
+		return nil
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("file locker: %w", err)
+	}
+	return nil
+}
+
+// refresh extends the lock for the key, if still held by this locker and not expired.
+func (l *fileLocker) refresh(key string, ttl time.Duration) (bool, error) {
+	path := l.path(key)
+
+	lock, found, err := l.read(path)
+	if err != nil {
+		return false, fmt.Errorf("file locker: %w", err)
+	}
+	if !found || lock.Owner != l.owner || !l.clock.Now().Before(lock.Expires) {
+		return false, nil
+	}
+
+	data, err := json.Marshal(fileLock{Owner: l.owner, Expires: l.clock.Now().Add(ttl)})
+	if err != nil {
+		return false, fmt.Errorf("file locker: %w", err)
+	}
+
+	err = writeTempFile(l.dir, ".lock-*", data, func(tmp string) error {
+		return os.Rename(tmp, path)
+	})
+	if err != nil {
+		return false, fmt.Errorf("file locker: %w", err)
+	}
+	return true, nil
+}
+
+// read returns the lock stored in the file, and whether there is one.
+func (l *fileLocker) read(path string) (fileLock, bool, error) {
+	var lock fileLock
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return lock, false, nil
+	}
+	if err != nil {
+		return lock, false, err
+	}
+
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return lock, false, fmt.Errorf("%s: %w", path, err)
+	}
+	return lock, true, nil
+}
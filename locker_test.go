@@ -0,0 +1,192 @@
+package runnable
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileLocker(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	replica1 := FileLocker(dir)
+	replica2 := FileLocker(dir)
+
+	locked, err := replica1.TryLock(ctx, "schedule/report", time.Minute)
+	require.NoError(t, err)
+	require.True(t, locked)
+
+	// Held, even by the same locker.
+	locked, err = replica1.TryLock(ctx, "schedule/report", time.Minute)
+	require.NoError(t, err)
+	require.False(t, locked)
+
+	locked, err = replica2.TryLock(ctx, "schedule/report", time.Minute)
+	require.NoError(t, err)
+	require.False(t, locked)
+
+	// Other keys are independent.
+	locked, err = replica2.TryLock(ctx, "schedule/cleanup", time.Minute)
+	require.NoError(t, err)
+	require.True(t, locked)
+
+	// Only the owner releases the lock.
+	require.NoError(t, replica2.Unlock(ctx, "schedule/report"))
+	locked, err = replica2.TryLock(ctx, "schedule/report", time.Minute)
+	require.NoError(t, err)
+	require.False(t, locked)
+
+	require.NoError(t, replica1.Unlock(ctx, "schedule/report"))
+	locked, err = replica2.TryLock(ctx, "schedule/report", time.Minute)
+	require.NoError(t, err)
+	require.True(t, locked)
+
+	// Releasing a lock that is not held does nothing.
+	require.NoError(t, replica1.Unlock(ctx, "schedule/unknown"))
+}
+
+func TestFileLocker_Expiry(t *testing.T) {
+	dir := t.TempDir()
+
+	synctest.Test(t, func(t *testing.T) {
+		ctx := context.Background()
+		replica1 := FileLocker(dir)
+		replica2 := FileLocker(dir)
+
+		locked, err := replica1.TryLock(ctx, "job", time.Minute)
+		require.NoError(t, err)
+		require.True(t, locked)
+
+		time.Sleep(time.Minute)
+
+		locked, err = replica2.TryLock(ctx, "job", time.Minute)
+		require.NoError(t, err)
+		require.True(t, locked)
+
+		// The expired owner does not release the new lock.
+		require.NoError(t, replica1.Unlock(ctx, "job"))
+		locked, err = replica1.TryLock(ctx, "job", time.Minute)
+		require.NoError(t, err)
+		require.False(t, locked)
+	})
+}
+
+func TestSchedule_Locker(t *testing.T) {
+	dir := t.TempDir()
+
+	synctest.Test(t, func(t *testing.T) {
+		var runs [2]int
+		var events [2][]ScheduleEvent
+
+		replica := func(i int) *schedule {
+			worker := Func(func(ctx context.Context) error {
+				runs[i]++
+				time.Sleep(time.Second)
+				return nil
+			}).Name("job")
+
+			return Schedule(worker, Every(time.Minute)).
+				Locker(FileLocker(dir), time.Minute).
+				OnEvent(func(e ScheduleEvent) { events[i] = append(events[i], e) })
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute+30*time.Second)
+		defer cancel()
+
+		errs := make(chan error, 2)
+		for i := range 2 {
+			go func() { errs <- replica(i).Run(ctx) }()
+		}
+		require.ErrorIs(t, <-errs, context.DeadlineExceeded)
+		require.ErrorIs(t, <-errs, context.DeadlineExceeded)
+
+		// Each tick ran once, on either replica.
+		require.Equal(t, 3, runs[0]+runs[1])
+		require.Len(t, append(events[0], events[1]...), 3)
+		for _, e := range append(events[0], events[1]...) {
+			require.Equal(t, ScheduleSkipped, e.Kind)
+			require.ErrorIs(t, e.Err, ErrLockHeld)
+		}
+	})
+}
+
+func TestSchedule_Locker_Concurrent(t *testing.T) {
+	dir := t.TempDir()
+
+	synctest.Test(t, func(t *testing.T) {
+		var runs atomic.Int32
+		worker := Func(func(ctx context.Context) error {
+			runs.Add(1)
+			time.Sleep(90 * time.Second)
+			return nil
+		})
+
+		var events []ScheduleEvent
+		s := Schedule(worker, Every(time.Minute)).
+			Overlap(OverlapConcurrent).
+			Locker(FileLocker(dir), 2*time.Minute).
+			OnEvent(func(e ScheduleEvent) { events = append(events, e) })
+
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute+30*time.Second)
+		defer cancel()
+
+		require.ErrorIs(t, s.Run(ctx), context.DeadlineExceeded)
+
+		// The executions overlap, and do not exclude each other.
+		require.Equal(t, int32(3), runs.Load())
+		require.Empty(t, events)
+	})
+}
+
+func TestSchedule_Locker_Splay(t *testing.T) {
+	dir := t.TempDir()
+
+	synctest.Test(t, func(t *testing.T) {
+		var runs [3]int
+		var skipped [3]int
+
+		replica := func(i int) *schedule {
+			worker := Func(func(ctx context.Context) error {
+				runs[i]++
+				time.Sleep(time.Second)
+				return nil
+			}).Name("job")
+
+			return Schedule(worker, Every(time.Hour)).
+				Splay(10*time.Minute, fmt.Sprintf("host-%d", i)).
+				Locker(FileLocker(dir), 15*time.Minute).
+				OnEvent(func(e ScheduleEvent) {
+					require.Equal(t, ScheduleSkipped, e.Kind)
+					require.ErrorIs(t, e.Err, ErrLockHeld)
+					skipped[i]++
+				})
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Hour+30*time.Minute)
+		defer cancel()
+
+		errs := make(chan error, 3)
+		for i := range 3 {
+			go func() { errs <- replica(i).Run(ctx) }()
+		}
+		for range 3 {
+			require.ErrorIs(t, <-errs, context.DeadlineExceeded)
+		}
+
+		// The replicas fire each tick at a different time, and each tick ran once.
+		require.Equal(t, 3, runs[0]+runs[1]+runs[2])
+		require.Equal(t, 6, skipped[0]+skipped[1]+skipped[2])
+
+		// The locks of the previous ticks were released once expired.
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+	})
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"runtime/pprof"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -20,11 +23,20 @@ import (
 // collected, except [context.Canceled] which is ignored. A manager is itself a
 // [Runnable], so managers can be nested for independent shutdown ordering.
 //
+// The cause of the cancellation of the runnables is the cause of the cancellation of
+// the manager context, like a [*SignalError], or [ErrParentShutdown] for a plain
+// cancellation.
+// When a runnable stops, it is a [*RunnableDiedError].
+//
+// A single runnable can be restarted without shutting down with [manager.RestartNow],
+// and the runnables can be reloaded with [manager.Reload].
+//
 // Registering the same runnable twice, or as both a process and a service, panics.
 func Manager() *manager {
 	return &manager{
 		name:            "manager",
 		shutdownTimeout: 10 * time.Second,
+		clock:           systemClock{},
 	}
 }
 
@@ -33,6 +45,23 @@ type manager struct {
 	processes       []Runnable
 	services        []Runnable
 	shutdownTimeout time.Duration
+	clock           Clock
+	stuckDump       io.Writer
+	cancelWatch     time.Duration
+
+	mu           sync.Mutex
+	children     map[Runnable]*child
+	running      bool
+	shuttingDown bool
+}
+
+// child tracks a running runnable.
+type child struct {
+	ctx        context.Context
+	cancel     context.CancelCauseFunc
+	restarting bool
+	started    time.Time
+	stopped    bool
 }
 
 func (m *manager) runnableName() string { return m.name }
@@ -50,6 +79,27 @@ func (m *manager) ShutdownTimeout(dur time.Duration) *manager {
 	return m
 }
 
+// Clock sets the clock used for the shutdown timeouts. Defaults to the system clock.
+func (m *manager) Clock(clock Clock) *manager {
+	m.clock = clock
+	return m
+}
+
+// StuckDump sets the writer receiving the goroutine stacks of the runnables still
+// running when a shutdown phase times out, to diagnose wedged shutdowns.
+func (m *manager) StuckDump(w io.Writer) *manager {
+	m.stuckDump = w
+	return m
+}
+
+// CancelWatch watches the runnables with [CancelWatch]: a warning is logged for each
+// runnable still running after the threshold once cancelled, and its goroutine stacks
+// are written to the [manager.StuckDump] writer, if any. Disabled by default.
+func (m *manager) CancelWatch(threshold time.Duration) *manager {
+	m.cancelWatch = threshold
+	return m
+}
+
 // ManagerRegistry is the interface for registering runnables with a Manager.
 type ManagerRegistry interface {
 	// Register registers processes. Processes are the primary runnables of the
@@ -100,31 +150,37 @@ type runnableSet map[Runnable]bool
 func (m *manager) Run(ctx context.Context) error {
 	prefix := m.runnableName()
 
-	svcCtx, svcCancel := context.WithCancel(context.WithoutCancel(ctx))
-	defer svcCancel()
+	svcCtx, svcCancel := context.WithCancelCause(context.WithoutCancel(ctx))
+	defer svcCancel(nil)
 
-	procCtx, procCancel := context.WithCancel(context.WithoutCancel(ctx))
-	defer procCancel()
+	procCtx, procCancel := context.WithCancelCause(context.WithoutCancel(ctx))
+	defer procCancel(nil)
 
 	svcDone := make(chan completed, len(m.services))
 	procDone := make(chan completed, len(m.processes))
 
+	m.mu.Lock()
+	m.children = map[Runnable]*child{}
+	m.running = true
+	m.shuttingDown = false
+	m.mu.Unlock()
+
+	defer func() {
+		m.mu.Lock()
+		m.running = false
+		m.mu.Unlock()
+	}()
+
 	for _, svc := range m.services {
-		go func() {
-			svcDone <- completed{svc, Recover(svc).Run(svcCtx)}
-		}()
-		logger.Info(prefix + "/" + runnableName(svc) + ": started")
+		m.start(svcCtx, svc, svcDone)
 	}
 
 	for _, proc := range m.processes {
-		go func() {
-			procDone <- completed{proc, Recover(proc).Run(procCtx)}
-		}()
-		logger.Info(prefix + "/" + runnableName(proc) + ": started")
+		m.start(procCtx, proc, procDone)
 	}
 
 	// Track completed runnables from the initial trigger.
-	var errs []string
+	errs := &managerError{name: prefix}
 	activeProcs := runnableSet{}
 	for _, p := range m.processes {
 		activeProcs[p] = true
@@ -135,69 +191,290 @@ func (m *manager) Run(ctx context.Context) error {
 	}
 
 	// Wait for context cancellation or any runnable to complete.
-	select {
-	case <-ctx.Done():
-		logger.Info(prefix+": starting shutdown", "reason", "context cancelled")
-	case c := <-procDone:
-		delete(activeProcs, c.runnable)
-		m.logCompleted(c)
-		m.collectError(&errs, c)
-		logger.Info(prefix+": starting shutdown", "reason", runnableName(c.runnable)+" died")
-	case c := <-svcDone:
-		delete(activeSvcs, c.runnable)
-		m.logCompleted(c)
-		m.collectError(&errs, c)
-		logger.Info(prefix+": starting shutdown", "reason", runnableName(c.runnable)+" died")
+	// Runnables restarted with RestartNow are started again.
+	var cause error
+	for cause == nil {
+		select {
+		case <-ctx.Done():
+			logger.Info(prefix+": starting shutdown", "reason", "context cancelled")
+			cause = shutdownCause(ctx)
+		case c := <-procDone:
+			if m.restartRequested(c) {
+				m.start(procCtx, c.runnable, procDone)
+				continue
+			}
+			delete(activeProcs, c.runnable)
+			m.logCompleted(c)
+			m.collectError(errs, c)
+			logger.Info(prefix+": starting shutdown", "reason", runnableName(c.runnable)+" died")
+			cause = &RunnableDiedError{runnableName(c.runnable), c.err}
+		case c := <-svcDone:
+			if m.restartRequested(c) {
+				m.start(svcCtx, c.runnable, svcDone)
+				continue
+			}
+			delete(activeSvcs, c.runnable)
+			m.logCompleted(c)
+			m.collectError(errs, c)
+			logger.Info(prefix+": starting shutdown", "reason", runnableName(c.runnable)+" died")
+			cause = &RunnableDiedError{runnableName(c.runnable), c.err}
+		}
 	}
 
+	m.mu.Lock()
+	m.shuttingDown = true
+	m.mu.Unlock()
+
+	// A forced shutdown stops waiting, as if the deadlines expired.
+	force := shutdownForced(ctx)
+
 	// Phase 1: stop processes
-	procCancel()
+	procCancel(cause)
 
-	deadline := time.After(m.shutdownTimeout)
+	deadline, stop := m.clock.NewTimer(m.shutdownTimeout)
 
 	for len(activeProcs) > 0 {
 		select {
 		case c := <-procDone:
 			delete(activeProcs, c.runnable)
 			m.logCompleted(c)
-			m.collectError(&errs, c)
+			m.collectError(errs, c)
 		case <-deadline:
-			for p := range activeProcs {
-				logger.Info(prefix + "/" + runnableName(p) + ": still running")
-				errs = append(errs, fmt.Sprintf("%s is still running", runnableName(p)))
-			}
+			m.abandon(errs, activeProcs)
+			activeProcs = nil
+		case <-force:
+			logger.Info(prefix + ": shutdown forced")
+			m.abandon(errs, activeProcs)
 			activeProcs = nil
 		}
 	}
 
+	stop()
+
 	// Phase 2: stop services
-	svcCancel()
+	svcCancel(cause)
 
-	deadline = time.After(m.shutdownTimeout)
+	deadline, stop = m.clock.NewTimer(m.shutdownTimeout)
 
 	for len(activeSvcs) > 0 {
 		select {
 		case c := <-svcDone:
 			delete(activeSvcs, c.runnable)
 			m.logCompleted(c)
-			m.collectError(&errs, c)
+			m.collectError(errs, c)
 		case <-deadline:
-			for s := range activeSvcs {
-				logger.Info(prefix + "/" + runnableName(s) + ": still running")
-				errs = append(errs, fmt.Sprintf("%s is still running", runnableName(s)))
-			}
+			m.abandon(errs, activeSvcs)
+			activeSvcs = nil
+		case <-force:
+			logger.Info(prefix + ": shutdown forced")
+			m.abandon(errs, activeSvcs)
 			activeSvcs = nil
 		}
 	}
 
+	stop()
+
 	logger.Info(prefix + ": shutdown complete")
 
-	if len(errs) > 0 {
-		return fmt.Errorf("%s: %s", prefix, strings.Join(errs, ", "))
+	if len(errs.msgs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// managerError is returned by a manager when runnables failed or did not stop in time.
+// It wraps the errors returned by the runnables, to find an [ExitCoder] for example.
+type managerError struct {
+	name string
+	msgs []string
+	errs []error
+}
+
+func (e *managerError) Error() string {
+	return e.name + ": " + strings.Join(e.msgs, ", ")
+}
+
+func (e *managerError) Unwrap() []error {
+	return e.errs
+}
+
+// start runs the runnable in a goroutine, with its own context so it can be restarted.
+func (m *manager) start(ctx context.Context, r Runnable, done chan<- completed) {
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	ch := &child{ctx: ctx, cancel: cancel, started: m.clock.Now()}
+	name := m.runnableName() + "/" + runnableName(r)
+
+	m.mu.Lock()
+	m.children[r] = ch
+	m.mu.Unlock()
+
+	go func() {
+		var err error
+		// The label relates the goroutine stacks to the runnable, see StuckDump.
+		pprof.Do(ctx, pprof.Labels(runnableLabel, name), func(ctx context.Context) {
+			err = m.wrap(r).Run(ctx)
+		})
+
+		m.mu.Lock()
+		ch.stopped = true
+		m.mu.Unlock()
+
+		done <- completed{r, err}
+	}()
+	logger.Info(m.runnableName() + "/" + runnableName(r) + ": started")
+}
+
+// wrap returns the runnable to run for r: recovering from panics, and watched if
+// enabled.
+func (m *manager) wrap(r Runnable) Runnable {
+	run := Recover(r)
+	if m.cancelWatch <= 0 {
+		return run
 	}
+	return CancelWatch(run, m.cancelWatch).Clock(m.clock).OnReport(func(latency CancelLatency) {
+		if m.stuckDump == nil || latency.Returned {
+			return
+		}
+		if _, err := io.WriteString(m.stuckDump, latency.Goroutines); err != nil {
+			logger.Info(latency.Name+": failed to dump goroutines", "error", err)
+		}
+	})
+}
+
+// restartRequested reports whether the completed runnable was stopped by RestartNow
+// and must be started again. A runnable that stopped by itself, even while a restart
+// was requested, is not restarted.
+func (m *manager) restartRequested(c completed) bool {
+	var requested *RestartRequestedError
+
+	m.mu.Lock()
+	ch := m.children[c.runnable]
+	restart := ch != nil && ch.restarting && errors.As(context.Cause(ch.ctx), &requested)
+	m.mu.Unlock()
+
+	if !restart {
+		return false
+	}
+	ch.cancel(nil)
+	m.logCompleted(c)
+	logger.Info(m.runnableName()+"/"+runnableName(c.runnable)+": restarting", "reason", requested.Reason)
+	return true
+}
+
+// RestartNow cancels a registered runnable and starts it again, without shutting down
+// the manager. The reason is logged, and the cause of the cancellation is a
+// [*RestartRequestedError]. It is safe for concurrent use.
+// Returns an error if the manager is not running or is shutting down.
+func (m *manager) RestartNow(r Runnable, reason string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ch := m.children[r]
+	if ch == nil || ch.stopped {
+		return fmt.Errorf("%s: %s is not running", m.runnableName(), runnableName(r))
+	}
+	if m.shuttingDown {
+		return fmt.Errorf("%s: shutting down", m.runnableName())
+	}
+
+	ch.restarting = true
+	ch.cancel(&RestartRequestedError{reason})
 	return nil
 }
 
+// Reload reloads the registered runnables implementing [Reloader], directly or through
+// wrappers, without restarting them: services first, then processes. Nested managers
+// reload their own runnables. Errors are logged, and returned joined. It is safe for
+// concurrent use, as long as the runnables are.
+func (m *manager) Reload(ctx context.Context) error {
+	var errs []error
+	for _, r := range slices.Concat(m.services, m.processes) {
+		name := m.runnableName() + "/" + runnableName(r)
+
+		supported, err := reload(ctx, r)
+		switch {
+		case !supported:
+		case err != nil:
+			logger.Info(name+": reload failed", "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", runnableName(r), err))
+		default:
+			logger.Info(name + ": reloaded")
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// shutdownCause returns the cause of the cancellation of the manager context, passed on
+// to the runnables. Causes that do not wrap [context.Canceled], like a plain
+// cancellation or a deadline, are replaced by [ErrParentShutdown], so that runnables
+// returning the cause are not reported as failed.
+func shutdownCause(ctx context.Context) error {
+	cause := context.Cause(ctx)
+	if cause == context.Canceled || !errors.Is(cause, context.Canceled) { //nolint:errorlint // plain cancellation
+		return ErrParentShutdown
+	}
+	return cause
+}
+
+// abandon reports the runnables that did not stop in time.
+func (m *manager) abandon(errs *managerError, active runnableSet) {
+	for r := range active {
+		name := m.runnableName() + "/" + runnableName(r)
+		logger.Info(name + ": still running")
+		errs.msgs = append(errs.msgs, fmt.Sprintf("%s is still running", runnableName(r)))
+
+		if m.stuckDump != nil {
+			if err := writeGoroutines(m.stuckDump, name); err != nil {
+				logger.Info(name+": failed to dump goroutines", "error", err)
+			}
+		}
+	}
+}
+
+// writeState writes the state of the manager and its runnables, indented. Nested
+// managers are written recursively.
+func (m *manager) writeState(w io.Writer, indent string) {
+	type entry struct {
+		runnable Runnable
+		line     string
+	}
+	var entries []entry
+
+	m.mu.Lock()
+	state := "not running"
+	switch {
+	case m.running && m.shuttingDown:
+		state = "shutting down"
+	case m.running:
+		state = "running"
+	}
+	for _, kind := range []string{"service", "process"} {
+		runnables := m.services
+		if kind == "process" {
+			runnables = m.processes
+		}
+		for _, r := range runnables {
+			childState := "not started"
+			if ch := m.children[r]; ch != nil && ch.stopped {
+				childState = "stopped"
+			} else if ch != nil {
+				childState = "running for " + m.clock.Now().Sub(ch.started).Round(time.Second).String()
+			}
+			entries = append(entries, entry{r, fmt.Sprintf("%s %s: %s", kind, runnableName(r), childState)})
+		}
+	}
+	m.mu.Unlock()
+
+	_, _ = fmt.Fprintf(w, "%s%s: %s\n", indent, m.runnableName(), state)
+	for _, e := range entries {
+		_, _ = fmt.Fprintf(w, "%s  %s\n", indent, e.line)
+		if nested := asManager(e.runnable); nested != nil {
+			nested.writeState(w, indent+"    ")
+		}
+	}
+}
+
 func (m *manager) logCompleted(c completed) {
 	name := m.runnableName() + "/" + runnableName(c.runnable)
 	if c.err == nil || errors.Is(c.err, context.Canceled) {
@@ -207,8 +484,9 @@ func (m *manager) logCompleted(c completed) {
 	}
 }
 
-func (m *manager) collectError(errs *[]string, c completed) {
+func (m *manager) collectError(errs *managerError, c completed) {
 	if c.err != nil && !errors.Is(c.err, context.Canceled) {
-		*errs = append(*errs, fmt.Sprintf("%s crashed with %+v", runnableName(c.runnable), c.err))
+		errs.msgs = append(errs.msgs, fmt.Sprintf("%s crashed with %+v", runnableName(c.runnable), c.err))
+		errs.errs = append(errs.errs, c.err)
 	}
 }
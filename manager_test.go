@@ -2,11 +2,16 @@ package runnable
 
 import (
 	"context"
+	"errors"
+	"os"
+	"sync/atomic"
 	"testing"
 	"testing/synctest"
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/pior/runnable/runnabletest"
 )
 
 type mockRunnable struct {
@@ -93,6 +98,102 @@ func TestManager_ShutdownTimeout(t *testing.T) {
 	})
 }
 
+func TestManager_Clock(t *testing.T) {
+	clock := runnabletest.NewClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	unblock := make(chan struct{})
+	defer close(unblock)
+	blocked := Func(func(ctx context.Context) error {
+		<-unblock
+		return nil
+	}).Name("blockedRunnable")
+
+	m := Manager().ShutdownTimeout(time.Hour).Clock(clock)
+	m.Register(blocked)
+
+	done := make(chan error)
+	go func() { done <- m.Run(cancelledContext()) }()
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Hour)
+	require.EqualError(t, <-done, "manager: blockedRunnable is still running")
+
+	// The deadline of the services phase, which had nothing to wait for, is stopped.
+	require.Empty(t, clock.Timers())
+}
+
+func TestManager_ShutdownForced(t *testing.T) {
+	unblock := make(chan struct{})
+	defer close(unblock)
+	blocked := Func(func(ctx context.Context) error {
+		<-unblock
+		return nil
+	}).Name("blockedRunnable")
+
+	m := Manager().ShutdownTimeout(time.Hour)
+	m.Register(blocked)
+
+	force := make(chan struct{})
+	close(force)
+	ctx := context.WithValue(cancelledContext(), forceKey{}, force)
+
+	require.EqualError(t, m.Run(ctx), "manager: blockedRunnable is still running")
+}
+
+func TestManager_CancellationCause(t *testing.T) {
+	// causeRecorder records the cause of its cancellation, and returns it.
+	causeRecorder := func(causes chan<- error) *funcRunnable {
+		return Func(func(ctx context.Context) error {
+			<-ctx.Done()
+			causes <- context.Cause(ctx)
+			return context.Cause(ctx)
+		}).Name("recorder")
+	}
+
+	t.Run("runnable died", func(t *testing.T) {
+		causes := make(chan error, 2)
+		dying := newDyingRunnable()
+
+		m := Manager()
+		m.Register(causeRecorder(causes), dying)
+		m.RegisterService(causeRecorder(causes))
+
+		require.EqualError(t, m.Run(context.Background()), "manager: dyingRunnable crashed with dying")
+
+		want := &RunnableDiedError{"dyingRunnable", errors.New("dying")}
+		require.Equal(t, want, <-causes)
+		require.Equal(t, want, <-causes)
+	})
+
+	t.Run("parent cause", func(t *testing.T) {
+		causes := make(chan error, 2)
+
+		sub := Manager().Name("sub")
+		sub.Register(causeRecorder(causes))
+
+		m := Manager()
+		m.Register(sub)
+		m.RegisterService(causeRecorder(causes))
+
+		ctx, cancel := context.WithCancelCause(context.Background())
+		cancel(&SignalError{os.Interrupt})
+		require.NoError(t, m.Run(ctx))
+
+		require.Equal(t, &SignalError{os.Interrupt}, <-causes)
+		require.Equal(t, &SignalError{os.Interrupt}, <-causes)
+	})
+
+	t.Run("plain cancellation", func(t *testing.T) {
+		causes := make(chan error, 1)
+
+		m := Manager()
+		m.Register(causeRecorder(causes))
+
+		require.NoError(t, m.Run(cancelledContext()))
+		require.Equal(t, ErrParentShutdown, <-causes)
+	})
+}
+
 func TestManager_ShutdownOrdering(t *testing.T) {
 	synctest.Test(t, func(t *testing.T) {
 		m := Manager()
@@ -225,6 +326,71 @@ func TestManager_Nested(t *testing.T) {
 	})
 }
 
+func TestManager_RestartNow(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		m := Manager()
+
+		proc := newMockRunnable()
+		var starts atomic.Int32
+		worker := Func(func(ctx context.Context) error {
+			starts.Add(1)
+			<-ctx.Done()
+			return ctx.Err()
+		}).Name("worker")
+
+		m.Register(proc, worker)
+
+		require.EqualError(t, m.RestartNow(worker, "rotate"), "manager: worker is not running")
+
+		errChan := make(chan error)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		go func() { errChan <- m.Run(ctx) }()
+
+		<-proc.calledChan
+		synctest.Wait()
+		require.Equal(t, int32(1), starts.Load())
+
+		require.NoError(t, m.RestartNow(worker, "rotate"))
+		synctest.Wait()
+		require.Equal(t, int32(2), starts.Load())
+		require.False(t, proc.cancelled) // the manager is not shutting down
+
+		cancel()
+
+		<-proc.cancelledChan
+		proc.errChan <- nil
+
+		require.NoError(t, <-errChan)
+		require.Equal(t, int32(2), starts.Load())
+	})
+}
+
+func TestManager_RestartNow_Crashed(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		m := Manager()
+
+		crash := make(chan struct{})
+		worker := Func(func(ctx context.Context) error {
+			<-crash
+			return errors.New("boom")
+		}).Name("worker")
+
+		m.Register(worker, newDummyRunnable())
+
+		errChan := make(chan error)
+		go func() { errChan <- m.Run(context.Background()) }()
+
+		synctest.Wait()
+		close(crash)
+		synctest.Wait()
+
+		// The crash is not taken for a requested restart.
+		require.Error(t, m.RestartNow(worker, "rotate"))
+		require.EqualError(t, <-errChan, "manager: worker crashed with boom")
+	})
+}
+
 func TestManager_DuplicateRegistration(t *testing.T) {
 	t.Run("process registered twice", func(t *testing.T) {
 		m := Manager()
@@ -31,6 +31,7 @@ type recoverRunner struct {
 }
 
 func (r *recoverRunner) runnableName() string { return r.name }
+func (r *recoverRunner) unwrap() Runnable     { return r.runnable }
 
 func (r *recoverRunner) Run(ctx context.Context) (err error) {
 	defer func() {
@@ -0,0 +1,37 @@
+package runnable
+
+import "context"
+
+// Reloader is implemented by runnables that can reload, like their configuration,
+// without restarting. See [Reload].
+type Reloader interface {
+	Reload(ctx context.Context) error
+}
+
+// unwrapper is implemented by wrappers to expose the wrapped runnable, so that a reload
+// reaches it.
+type unwrapper interface {
+	unwrap() Runnable
+}
+
+// Reload reloads the runnable if it implements [Reloader], or else the runnable it wraps,
+// through any number of wrappers. A [Manager] reloads all its runnables.
+// Reload does nothing when no runnable supports reloading.
+func Reload(ctx context.Context, runnable Runnable) error {
+	_, err := reload(ctx, runnable)
+	return err
+}
+
+// reload reloads the runnable, and reports whether it supports reloading.
+func reload(ctx context.Context, runnable Runnable) (bool, error) {
+	for {
+		if r, ok := runnable.(Reloader); ok {
+			return true, r.Reload(ctx)
+		}
+		u, ok := runnable.(unwrapper)
+		if !ok {
+			return false, nil
+		}
+		runnable = u.unwrap()
+	}
+}
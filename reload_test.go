@@ -0,0 +1,61 @@
+package runnable
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type reloadable struct {
+	dummyRunnable
+	reloads atomic.Int64
+	err     error
+}
+
+func (r *reloadable) Reload(context.Context) error {
+	r.reloads.Add(1)
+	return r.err
+}
+
+func TestReload(t *testing.T) {
+	ctx := context.Background()
+
+	r := &reloadable{}
+	require.NoError(t, Reload(ctx, r))
+	require.EqualValues(t, 1, r.reloads.Load())
+
+	// Through wrappers.
+	require.NoError(t, Reload(ctx, Signal(Restart(CircuitBreaker(r)))))
+	require.EqualValues(t, 2, r.reloads.Load())
+
+	failing := &reloadable{err: errors.New("invalid config")}
+	require.EqualError(t, Reload(ctx, Schedule(failing, Hourly())), "invalid config")
+
+	// Not supported.
+	require.NoError(t, Reload(ctx, newDummyRunnable()))
+}
+
+func TestManager_Reload(t *testing.T) {
+	ctx := context.Background()
+
+	process := &reloadable{}
+	service := &reloadable{err: errors.New("invalid config")}
+	nested := &reloadable{}
+
+	sub := Manager().Name("sub")
+	sub.Register(Restart(nested))
+
+	m := Manager()
+	m.Register(process, newDummyRunnable(), sub)
+	m.RegisterService(service)
+
+	err := m.Reload(ctx)
+	require.EqualError(t, err, "reloadable: invalid config")
+
+	require.EqualValues(t, 1, process.reloads.Load())
+	require.EqualValues(t, 1, service.reloads.Load())
+	require.EqualValues(t, 1, nested.reloads.Load())
+}
@@ -2,6 +2,10 @@ package runnable
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
 	"time"
 )
 
@@ -16,15 +20,24 @@ import (
 // run. Use [restart.ErrorResetAfter] to also reset after a run that lasted long
 // enough before failing.
 //
-// Restart loops indefinitely unless limited by [restart.Limit] or [restart.ErrorLimit].
-// When the restart limit is reached, Restart returns nil. When the error limit is
-// reached, Restart returns the last error.
+// Errors marked with [Permanent] stop the loop immediately, and errors marked with
+// [Transient] are retried without counting toward the error limit. Use
+// [restart.ErrorClassifier] to classify errors that cannot be wrapped at the source.
+//
+// Use [restart.RestartNow] to cancel the current run and restart immediately.
+//
+// Restart loops indefinitely unless limited by [restart.Limit], [restart.ErrorLimit] or
+// [restart.Intensity]. When the restart limit is reached, Restart returns nil. When the
+// error limit is reached, Restart returns the last error. When the restart intensity is
+// exceeded, Restart returns an [*IntensityError].
 // Context cancellation stops the loop and returns [context.Canceled].
 func Restart(runnable Runnable) *restart {
 	return &restart{
 		name:           "restart/" + runnableName(runnable),
 		runnable:       Recover(runnable),
 		errorBackoffFn: defaultErrorBackoff,
+		requests:       make(chan string, 1),
+		clock:          systemClock{},
 	}
 }
 
@@ -36,11 +49,33 @@ type restart struct {
 	delay           time.Duration
 	errorBackoffFn  func(int) time.Duration
 	errorResetAfter time.Duration
+	classifier      func(error) ErrorClass
+	intensity       int
+	intensityWindow time.Duration
+	clock           Clock
+
+	requests chan string
+
+	mu    sync.Mutex
+	stats RestartStats
 }
 
+// ErrorClass tells [Restart] how to handle an error returned by the runnable.
+type ErrorClass int
+
+const (
+	// ErrorRetry restarts the runnable and counts the error toward the error limit.
+	ErrorRetry ErrorClass = iota
+	// ErrorPermanent stops the loop and returns the error.
+	ErrorPermanent
+	// ErrorTransient restarts the runnable without counting the error.
+	ErrorTransient
+)
+
 var _ Runnable = (*restart)(nil)
 
 func (r *restart) runnableName() string { return r.name }
+func (r *restart) unwrap() Runnable     { return r.runnable }
 
 // Limit sets the maximum number of restarts after successful (nil) exits.
 // When reached, returns nil. Zero means unlimited (the default).
@@ -81,52 +116,258 @@ func (r *restart) ErrorResetAfter(d time.Duration) *restart {
 	return r
 }
 
+// Intensity limits the restarts to at most n within the given sliding window, counting
+// restarts after both successful exits and errors. When exceeded, Restart returns an
+// [*IntensityError] carrying the recent runs. Zero means unlimited (the default).
+func (r *restart) Intensity(n int, window time.Duration) *restart {
+	r.intensity = n
+	r.intensityWindow = window
+	return r
+}
+
+// ErrorClassifier sets the function that classifies errors not explicitly marked with
+// [Permanent] or [Transient]. By default, all other errors are [ErrorRetry].
+func (r *restart) ErrorClassifier(fn func(error) ErrorClass) *restart {
+	r.classifier = fn
+	return r
+}
+
+// Clock sets the clock used for the delays and the statistics.
+// Defaults to the system clock.
+func (r *restart) Clock(clock Clock) *restart {
+	r.clock = clock
+	return r
+}
+
+func (r *restart) classify(err error) ErrorClass {
+	var permanent *PermanentError
+	if errors.As(err, &permanent) {
+		return ErrorPermanent
+	}
+	var transient *TransientError
+	if errors.As(err, &transient) {
+		return ErrorTransient
+	}
+	if r.classifier != nil {
+		return r.classifier(err)
+	}
+	return ErrorRetry
+}
+
 func (r *restart) Run(ctx context.Context) error {
-	restartCount := 0
-	errorCount := 0
+	var stats RestartStats
+	var history []RestartRecord
+
+	r.setStats(stats)
+	defer func() { r.setStats(stats) }()
+
+	// Drop a request made while the runnable was not running.
+	select {
+	case <-r.requests:
+	default:
+	}
 
 	for {
-		logger.Info(r.name+": starting", "restart", restartCount, "errors", errorCount)
+		logger.Info(r.name+": starting", "restart", stats.Restarts, "errors", stats.ConsecutiveErrors)
+
+		stats.LastStart = r.clock.Now()
+		stats.NextStart = time.Time{}
+		r.setStats(stats)
+
+		reason, requested, err := r.runOnce(ctx)
 
-		startTime := time.Now()
-		err := r.runnable.Run(ctx)
+		stats.LastExit = r.clock.Now()
 
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
 
+		if requested {
+			logger.Info(r.name+": restart requested", "reason", reason)
+			stats.Restarts++
+			continue
+		}
+
+		if err != nil {
+			stats.LastError = err
+		}
+
+		class := ErrorRetry
 		if err != nil {
-			if r.errorResetAfter > 0 && time.Since(startTime) >= r.errorResetAfter {
-				errorCount = 0
+			class = r.classify(err)
+		}
+
+		switch {
+		case class == ErrorPermanent:
+			logger.Info(r.name+": not restarting", "reason", "permanent error", "error", err)
+			return err
+		case class == ErrorTransient:
+			// Expected condition: restart without touching the error count.
+		case err != nil:
+			if r.errorResetAfter > 0 && stats.LastExit.Sub(stats.LastStart) >= r.errorResetAfter {
+				stats.ConsecutiveErrors = 0
 			}
-			errorCount++
+			stats.ConsecutiveErrors++
 
-			if r.errorLimit > 0 && errorCount >= r.errorLimit {
+			if r.errorLimit > 0 && stats.ConsecutiveErrors >= r.errorLimit {
 				logger.Info(r.name+": not restarting", "reason", "error limit", "limit", r.errorLimit)
 				return err
 			}
-		} else {
-			errorCount = 0
+		default:
+			stats.ConsecutiveErrors = 0
 
-			if r.limit > 0 && restartCount >= r.limit {
+			if r.limit > 0 && stats.Restarts >= r.limit {
 				logger.Info(r.name+": not restarting", "reason", "restart limit", "limit", r.limit)
 				return nil
 			}
 		}
 
-		restartCount++
+		if r.intensity > 0 {
+			history = r.recordRun(history, RestartRecord{stats.LastStart, stats.LastExit, err})
+			if len(history) > r.intensity {
+				logger.Info(r.name+": not restarting", "reason", "restart intensity",
+					"limit", r.intensity, "window", r.intensityWindow)
+				return &IntensityError{r.intensity, r.intensityWindow, history}
+			}
+		}
+
+		stats.Restarts++
 
 		delay := r.delay
 		if err != nil {
-			delay = r.errorBackoffFn(errorCount)
+			delay = r.errorBackoffFn(max(stats.ConsecutiveErrors, 1))
 		}
 
+		stats.NextStart = r.clock.Now().Add(delay)
+		r.setStats(stats)
+
+		backoff, stop := r.clock.NewTimer(delay)
 		select {
 		case <-ctx.Done():
+			stop()
 			return ctx.Err()
-		case <-time.After(delay):
+		case reason := <-r.requests:
+			stop()
+			logger.Info(r.name+": restart requested", "reason", reason)
+		case <-backoff:
+		}
+	}
+}
+
+// RestartNow cancels the current run and restarts the runnable immediately, bypassing
+// the backoff and the limits. When called during a backoff, the wait is skipped.
+// The reason is logged, and the cause of the cancellation is a [*RestartRequestedError].
+// It is safe for concurrent use, and does nothing if a restart is already pending.
+func (r *restart) RestartNow(reason string) {
+	select {
+	case r.requests <- reason:
+	default:
+	}
+}
+
+// runOnce runs the runnable until it returns or a restart is requested. A run is
+// restarted as requested only if the request cancelled it: a request arriving once the
+// runnable returned by itself is left for the backoff.
+func (r *restart) runOnce(ctx context.Context) (string, bool, error) {
+	runCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var request *RestartRequestedError
+	watched := make(chan struct{})
+	go func() {
+		defer close(watched)
+		select {
+		case reason := <-r.requests:
+			request = &RestartRequestedError{reason}
+			cancel(request)
+		case <-runCtx.Done():
+		}
+	}()
+
+	err := r.runnable.Run(runCtx)
+	cancel(nil)
+	<-watched
+
+	if request == nil {
+		return "", false, err
+	}
+	if context.Cause(runCtx) != error(request) { //nolint:errorlint // our own cause
+		r.RestartNow(request.Reason) // the runnable had already returned
+		return "", false, err
+	}
+	return request.Reason, true, err
+}
+
+// RestartStats describes the state of a runnable managed by [Restart].
+type RestartStats struct {
+	Restarts          int       // total number of restarts
+	ConsecutiveErrors int       // errors since the last successful run
+	LastError         error     // error of the last failed run
+	LastStart         time.Time // start of the current or last run
+	LastExit          time.Time // exit of the last run
+	NextStart         time.Time // planned restart after backoff, zero while running
+}
+
+// Stats returns the restart statistics of the current or last Run.
+// It is safe for concurrent use.
+func (r *restart) Stats() RestartStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stats
+}
+
+func (r *restart) setStats(stats RestartStats) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stats = stats
+}
+
+// recordRun appends the run to the history and drops the runs that exited
+// outside of the intensity window.
+func (r *restart) recordRun(history []RestartRecord, run RestartRecord) []RestartRecord {
+	history = append(history, run)
+	cutoff := run.Exit.Add(-r.intensityWindow)
+	for len(history) > 0 && history[0].Exit.Before(cutoff) {
+		history = history[1:]
+	}
+	return history
+}
+
+// RestartRecord describes a single run of a runnable managed by [Restart].
+type RestartRecord struct {
+	Start time.Time
+	Exit  time.Time
+	Err   error // nil for a successful exit
+}
+
+// IntensityError is returned by [Restart] when the runnable restarted more often
+// than allowed by [restart.Intensity].
+type IntensityError struct {
+	Limit  int
+	Window time.Duration
+	// History holds the runs that exited within the window, oldest first.
+	History []RestartRecord
+}
+
+func (e *IntensityError) Error() string {
+	msg := fmt.Sprintf("restarted more than %d times in %s", e.Limit, e.Window)
+	for _, run := range slices.Backward(e.History) {
+		if run.Err != nil {
+			return msg + ": " + run.Err.Error()
+		}
+	}
+	return msg
+}
+
+// Unwrap returns the errors of the runs in the history.
+func (e *IntensityError) Unwrap() []error {
+	var errs []error
+	for _, run := range e.History {
+		if run.Err != nil {
+			errs = append(errs, run.Err)
 		}
 	}
+	return errs
 }
 
 func defaultErrorBackoff(errorCount int) time.Duration {
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/pior/runnable/runnabletest"
 )
 
 func TestRestart(t *testing.T) {
@@ -83,6 +85,236 @@ func TestRestart(t *testing.T) {
 		require.Equal(t, "runnable panicked: boom", err.Error())
 	})
 
+	t.Run("permanent error", func(t *testing.T) {
+		callCount := 0
+		fn := Func(func(ctx context.Context) error {
+			callCount++
+			return Permanent(errors.New("bad config"))
+		})
+
+		r := Restart(fn).ErrorLimit(10)
+		err := r.Run(context.Background())
+		require.EqualError(t, err, "bad config")
+		require.ErrorAs(t, err, new(*PermanentError))
+		require.Equal(t, 1, callCount)
+	})
+
+	t.Run("transient errors do not count", func(t *testing.T) {
+		// Alternates: transient, error, transient, error, ...
+		// Only the non-transient errors count toward the limit.
+		callCount := 0
+		fn := Func(func(ctx context.Context) error {
+			callCount++
+			if callCount%2 == 1 {
+				return Transient(errors.New("busy"))
+			}
+			return errors.New("fail")
+		})
+
+		r := Restart(fn).
+			ErrorLimit(3).
+			ErrorBackoff(func(int) time.Duration { return 0 })
+		err := r.Run(context.Background())
+		require.EqualError(t, err, "fail")
+		require.Equal(t, 6, callCount)
+	})
+
+	t.Run("error classifier", func(t *testing.T) {
+		errNotFound := errors.New("not found")
+		callCount := 0
+		fn := Func(func(ctx context.Context) error {
+			callCount++
+			if callCount == 3 {
+				return errNotFound
+			}
+			return errors.New("fail")
+		})
+
+		r := Restart(fn).
+			ErrorBackoff(func(int) time.Duration { return 0 }).
+			ErrorClassifier(func(err error) ErrorClass {
+				if errors.Is(err, errNotFound) {
+					return ErrorPermanent
+				}
+				return ErrorRetry
+			})
+		err := r.Run(context.Background())
+		require.ErrorIs(t, err, errNotFound)
+		require.Equal(t, 3, callCount)
+	})
+
+	t.Run("restart intensity", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			// Crashes every 31s: the error count keeps resetting, but the
+			// sliding window catches the crash loop.
+			callCount := 0
+			fn := Func(func(ctx context.Context) error {
+				callCount++
+				time.Sleep(31 * time.Second)
+				return errors.New("crash")
+			})
+
+			r := Restart(fn).
+				ErrorLimit(3).
+				ErrorResetAfter(30*time.Second).
+				Intensity(3, 2*time.Minute)
+			err := r.Run(context.Background())
+
+			var intensityErr *IntensityError
+			require.ErrorAs(t, err, &intensityErr)
+			require.EqualError(t, err, "restarted more than 3 times in 2m0s: crash")
+			require.Len(t, intensityErr.History, 4)
+			require.Equal(t, 4, callCount)
+		})
+	})
+
+	t.Run("restart intensity counts successful exits", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			counter := newCounterRunnable()
+
+			r := Restart(counter).
+				Delay(10*time.Second).
+				Intensity(5, time.Minute)
+			err := r.Run(context.Background())
+
+			var intensityErr *IntensityError
+			require.ErrorAs(t, err, &intensityErr)
+			require.EqualError(t, err, "restarted more than 5 times in 1m0s")
+			require.Equal(t, 6, counter.counter)
+		})
+	})
+
+	t.Run("restart intensity window slides", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			counter := newCounterRunnable()
+
+			r := Restart(counter).
+				Limit(20).
+				Delay(90*time.Second).
+				Intensity(2, 2*time.Minute)
+			err := r.Run(context.Background())
+			require.NoError(t, err) // restarts are spread out, only the limit applies
+
+			require.Equal(t, 21, counter.counter)
+		})
+	})
+
+	t.Run("stats", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			var count atomic.Int32
+			fn := Func(func(ctx context.Context) error {
+				if count.Add(1) == 3 {
+					<-ctx.Done()
+					return ctx.Err()
+				}
+				time.Sleep(time.Second)
+				return errors.New("fail")
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+
+			r := Restart(fn).ErrorBackoff(func(n int) time.Duration {
+				return 10 * time.Second
+			})
+			require.Equal(t, RestartStats{}, r.Stats())
+
+			errChan := make(chan error, 1)
+			go func() {
+				errChan <- r.Run(ctx)
+			}()
+
+			start := time.Now()
+
+			// First run failed after 1s, waiting for the backoff.
+			time.Sleep(2 * time.Second)
+			synctest.Wait()
+			stats := r.Stats()
+			require.Equal(t, 1, stats.Restarts)
+			require.Equal(t, 1, stats.ConsecutiveErrors)
+			require.EqualError(t, stats.LastError, "fail")
+			require.Equal(t, start, stats.LastStart)
+			require.Equal(t, start.Add(time.Second), stats.LastExit)
+			require.Equal(t, start.Add(11*time.Second), stats.NextStart)
+
+			// Third run is running.
+			time.Sleep(21 * time.Second)
+			synctest.Wait()
+			stats = r.Stats()
+			require.Equal(t, 2, stats.Restarts)
+			require.Equal(t, 2, stats.ConsecutiveErrors)
+			require.Equal(t, start.Add(22*time.Second), stats.LastStart)
+			require.True(t, stats.NextStart.IsZero())
+
+			cancel()
+			<-errChan
+		})
+	})
+
+	t.Run("restart now", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			var count atomic.Int32
+			causes := make(chan error, 2)
+			fn := Func(func(ctx context.Context) error {
+				count.Add(1)
+				<-ctx.Done()
+				causes <- context.Cause(ctx)
+				return ctx.Err()
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+
+			r := Restart(fn)
+
+			errChan := make(chan error, 1)
+			go func() {
+				errChan <- r.Run(ctx)
+			}()
+
+			synctest.Wait()
+			require.Equal(t, int32(1), count.Load())
+
+			r.RestartNow("credentials rotated")
+			synctest.Wait()
+			require.Equal(t, int32(2), count.Load())
+			require.Equal(t, 1, r.Stats().Restarts)
+			require.NoError(t, r.Stats().LastError)
+			require.Equal(t, &RestartRequestedError{"credentials rotated"}, <-causes)
+
+			cancel()
+			require.ErrorIs(t, <-errChan, context.Canceled)
+			require.Equal(t, context.Canceled, <-causes)
+		})
+	})
+
+	t.Run("restart now skips backoff", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			var count atomic.Int32
+			fn := Func(func(ctx context.Context) error {
+				count.Add(1)
+				return errors.New("fail")
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+
+			r := Restart(fn).ErrorBackoff(func(int) time.Duration { return time.Hour })
+
+			errChan := make(chan error, 1)
+			go func() {
+				errChan <- r.Run(ctx)
+			}()
+
+			synctest.Wait()
+			require.Equal(t, int32(1), count.Load())
+
+			r.RestartNow("retry now")
+			synctest.Wait()
+			require.Equal(t, int32(2), count.Load())
+
+			cancel()
+			require.ErrorIs(t, <-errChan, context.Canceled)
+		})
+	})
+
 	t.Run("error backoff", func(t *testing.T) {
 		synctest.Test(t, func(t *testing.T) {
 			var count atomic.Int32
@@ -196,3 +428,57 @@ func ExampleRestart_worker() {
 	// level=INFO msg="restart/counter: starting" restart=2 errors=0
 	// level=INFO msg="restart/counter: not restarting" reason="restart limit" limit=2
 }
+
+func TestRestart_Clock(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := runnabletest.NewClock(start)
+
+	dying := newDyingRunnable()
+	r := Restart(dying).
+		ErrorLimit(3).
+		ErrorBackoff(func(int) time.Duration { return time.Minute }).
+		Clock(clock)
+
+	done := make(chan error)
+	go func() { done <- r.Run(context.Background()) }()
+
+	clock.BlockUntil(1)
+	require.Equal(t, start.Add(time.Minute), r.Stats().NextStart)
+	clock.Advance(time.Minute)
+
+	clock.BlockUntil(1)
+	require.Equal(t, start.Add(2*time.Minute), r.Stats().NextStart)
+	clock.Advance(time.Minute)
+
+	require.EqualError(t, <-done, "dying")
+	require.Equal(t, 3, dying.counter)
+	require.Equal(t, start.Add(2*time.Minute), r.Stats().LastExit)
+}
+
+func TestRestart_Clock_RestartNow(t *testing.T) {
+	clock := runnabletest.NewClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	runs := make(chan struct{})
+	worker := Func(func(ctx context.Context) error {
+		runs <- struct{}{}
+		return errors.New("boom")
+	})
+	r := Restart(worker).ErrorBackoff(func(int) time.Duration { return time.Minute }).Clock(clock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- r.Run(ctx) }()
+
+	<-runs
+	clock.BlockUntil(1)
+	r.RestartNow("deploy")
+
+	// The backoff skipped by the request is stopped.
+	<-runs
+	clock.BlockUntil(1)
+	require.Len(t, clock.Timers(), 1)
+
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+	require.Empty(t, clock.Timers())
+}
@@ -3,9 +3,14 @@ package runnable
 import (
 	"context"
 	"errors"
-	stdlog "log"
 )
 
+// ExitCoder is implemented by errors that map to a specific process exit code, like 2
+// for a configuration error. See [Main].
+type ExitCoder interface {
+	ExitCode() int
+}
+
 // RunGroup runs all runnables in a Manager, and listens to SIGTERM/SIGINT.
 func RunGroup(runners ...Runnable) {
 	m := Manager()
@@ -14,11 +19,12 @@ func RunGroup(runners ...Runnable) {
 }
 
 // Run runs a single runnable, and listens to SIGTERM/SIGINT.
+// It returns when the runnable returns nil or [context.Canceled], including after a
+// signal. On other errors, it logs the error and exits the process with the exit code
+// of the first error implementing [ExitCoder], or 1.
 func Run(runner Runnable) {
-	ctx := context.Background()
-	err := Signal(runner).Run(ctx)
-	if err != nil && !errors.Is(err, context.Canceled) {
-		stdlog.Fatal(err)
+	if code := run(runner, false); code != 0 {
+		osExit(code)
 	}
 }
 
@@ -26,3 +32,58 @@ func Run(runner Runnable) {
 func RunFunc(fn RunnableFunc) {
 	Run(Func(fn))
 }
+
+// Main runs a single runnable, listens to SIGTERM/SIGINT, and returns the exit code of
+// the process instead of exiting:
+//   - 128 + the signal number when the runnable stopped after a signal, returning nil
+//     or [context.Canceled], like 130 for SIGINT and 143 for SIGTERM,
+//   - 0 when the runnable returns nil or [context.Canceled] otherwise,
+//   - the exit code of the first error implementing [ExitCoder] in the error tree,
+//     like [*SignalError],
+//   - 1 for other errors.
+//
+// The error is logged with the logger set with [SetLogger].
+func Main(runner Runnable) int {
+	return run(runner, true)
+}
+
+// run runs the runnable with [Signal], logs the error, and returns the exit code. A
+// shutdown caused by a signal exits with the code of the signal only if signalCode is
+// set, and with 0 otherwise.
+func run(runner Runnable, signalCode bool) int {
+	s := Signal(runner)
+	err := s.Run(context.Background())
+
+	stopped := err == nil || errors.Is(err, context.Canceled)
+	switch {
+	case stopped && !signalCode:
+		return 0
+	case stopped && s.signalled() != nil:
+		err = s.signalled()
+	}
+
+	code := exitCode(err)
+
+	var sigErr *SignalError
+	switch {
+	case code == 0:
+	case errors.As(err, &sigErr):
+		logger.Info(runnableName(runner)+": stopped by signal", "signal", sigErr.Signal, "code", code)
+	default:
+		logger.Error(runnableName(runner)+": failed", "error", err, "code", code)
+	}
+	return code
+}
+
+// exitCode returns the process exit code for the error returned by a runnable.
+func exitCode(err error) int {
+	var coder ExitCoder
+	switch {
+	case errors.As(err, &coder):
+		return coder.ExitCode()
+	case err == nil || errors.Is(err, context.Canceled):
+		return 0
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,65 @@
+package runnable
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type configError struct{}
+
+func (configError) Error() string { return "invalid config" }
+func (configError) ExitCode() int { return 2 }
+
+func TestMain_ExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"success", nil, 0},
+		{"cancelled", context.Canceled, 0},
+		{"error", errors.New("boom"), 1},
+		{"exit coder", fmt.Errorf("loading: %w", configError{}), 2},
+		{"signal", &SignalError{syscall.SIGINT}, 130},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn := Func(func(ctx context.Context) error { return tt.err })
+			require.Equal(t, tt.code, Main(fn))
+		})
+	}
+
+	t.Run("through a manager", func(t *testing.T) {
+		m := Manager()
+		m.Register(Func(func(ctx context.Context) error { return configError{} }), newDummyRunnable())
+		require.Equal(t, 2, Main(m))
+	})
+}
+
+func TestMain_LogsError(t *testing.T) {
+	var logs bytes.Buffer
+	SetLogger(slog.New(slog.NewTextHandler(&logs, nil)))
+	defer SetLogger(nil)
+
+	fn := Func(func(ctx context.Context) error { return configError{} }).Name("app")
+	require.Equal(t, 2, Main(fn))
+	require.Contains(t, logs.String(), `level=ERROR msg="app: failed" error="invalid config" code=2`)
+}
+
+func TestRun_Exit(t *testing.T) {
+	defer func(exit func(int)) { osExit = exit }(osExit)
+	var codes []int
+	osExit = func(code int) { codes = append(codes, code) }
+
+	Run(Func(func(ctx context.Context) error { return nil }))
+	Run(Func(func(ctx context.Context) error { return errors.New("boom") }))
+	require.Equal(t, []int{1}, codes)
+}
@@ -0,0 +1,140 @@
+// Package runnabletest provides helpers to test runnables.
+package runnabletest
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Clock is a manual clock implementing runnable.Clock. Its time only moves when
+// advanced with [Clock.Advance] or [Clock.Set], so tests of time-based wrappers run
+// instantly and deterministically:
+//
+//	clock := runnabletest.NewClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+//	go runnable.Schedule(job, runnable.DailyAt(3, 0)).Clock(clock).Run(ctx)
+//
+//	clock.BlockUntil(1) // the schedule waits for the next tick
+//	clock.Advance(3 * time.Hour)
+//
+// It is safe for concurrent use.
+type Clock struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	timers []*timer
+}
+
+type timer struct {
+	deadline time.Time
+	c        chan time.Time
+}
+
+// NewClock returns a manual clock set to the given time.
+func NewClock(now time.Time) *Clock {
+	c := &Clock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the current time of the clock.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel receiving the time of the clock once it has been advanced
+// by at least the given duration. A duration of zero or less fires immediately.
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	ch, _ := c.NewTimer(d)
+	return ch
+}
+
+// NewTimer is like [Clock.After], and also returns a function stopping the timer,
+// which then is no longer pending. Stop reports whether the timer was stopped before
+// it fired.
+func (c *Clock) NewTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &timer{deadline: c.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.c <- c.now
+		return t.c, func() bool { return false }
+	}
+
+	c.timers = append(c.timers, t)
+	c.cond.Broadcast()
+	return t.c, func() bool { return c.stop(t) }
+}
+
+// stop removes the timer from the pending timers, and reports whether it was pending.
+func (c *Clock) stop(t *timer) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := slices.Index(c.timers, t)
+	if i < 0 {
+		return false
+	}
+	c.timers = slices.Delete(c.timers, i, i+1)
+	c.cond.Broadcast()
+	return true
+}
+
+// Advance moves the clock forward by the given duration, and fires the timers
+// that expired.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(c.now.Add(d))
+}
+
+// Set moves the clock to the given time, and fires the timers that expired.
+// Moving the clock backward fires nothing.
+func (c *Clock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(now)
+}
+
+func (c *Clock) set(now time.Time) {
+	c.now = now
+
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.deadline.After(now) {
+			pending = append(pending, t)
+		} else {
+			t.c <- now
+		}
+	}
+	clear(c.timers[len(pending):])
+	c.timers = pending
+	c.cond.Broadcast()
+}
+
+// Timers returns the deadlines of the pending timers, earliest first. Stopped timers
+// are not pending.
+func (c *Clock) Timers() []time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deadlines := make([]time.Time, len(c.timers))
+	for i, t := range c.timers {
+		deadlines[i] = t.deadline
+	}
+	slices.SortFunc(deadlines, func(a, b time.Time) int { return a.Compare(b) })
+	return deadlines
+}
+
+// BlockUntil blocks until at least n timers are pending. Use it to wait for the code
+// under test to reach a wait before advancing the clock.
+func (c *Clock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.timers) < n {
+		c.cond.Wait()
+	}
+}
@@ -0,0 +1,66 @@
+package runnabletest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClock(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewClock(start)
+	require.Equal(t, start, clock.Now())
+
+	minute := clock.After(time.Minute)
+	hour := clock.After(time.Hour)
+	require.Equal(t, []time.Time{start.Add(time.Minute), start.Add(time.Hour)}, clock.Timers())
+
+	clock.Advance(30 * time.Second)
+	require.Empty(t, minute)
+
+	clock.Advance(30 * time.Second)
+	require.Equal(t, start.Add(time.Minute), <-minute)
+	require.Equal(t, []time.Time{start.Add(time.Hour)}, clock.Timers())
+
+	clock.Set(start.Add(2 * time.Hour))
+	require.Equal(t, start.Add(2*time.Hour), <-hour)
+	require.Empty(t, clock.Timers())
+
+	// Zero and negative durations fire immediately.
+	require.Equal(t, start.Add(2*time.Hour), <-clock.After(0))
+	require.Equal(t, start.Add(2*time.Hour), <-clock.After(-time.Second))
+	require.Empty(t, clock.Timers())
+}
+
+func TestClock_BlockUntil(t *testing.T) {
+	clock := NewClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	fired := make(chan time.Time)
+	go func() {
+		fired <- <-clock.After(time.Minute)
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	require.Equal(t, time.Date(2025, 1, 1, 0, 1, 0, 0, time.UTC), <-fired)
+}
+
+func TestClock_NewTimer(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewClock(start)
+
+	minute, stopMinute := clock.NewTimer(time.Minute)
+	_, stopHour := clock.NewTimer(time.Hour)
+	require.Len(t, clock.Timers(), 2)
+
+	// A stopped timer is no longer pending, and never fires.
+	require.True(t, stopHour())
+	require.False(t, stopHour())
+	require.Equal(t, []time.Time{start.Add(time.Minute)}, clock.Timers())
+
+	clock.Advance(2 * time.Hour)
+	require.Equal(t, start.Add(2*time.Hour), <-minute)
+	require.False(t, stopMinute())
+	require.Empty(t, clock.Timers())
+}
@@ -0,0 +1,83 @@
+package runnabletest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime/pprof"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Runnable is the interface of runnable.Runnable.
+type Runnable interface {
+	Run(ctx context.Context) error
+}
+
+// stopLabel is the pprof label set on the goroutines of the runnables run by
+// [RequireStop], to report their stacks.
+const stopLabel = "runnabletest"
+
+var stopCount atomic.Int64
+
+// RequireStop runs the runnable for the given duration, cancels its context, and fails
+// the test if the runnable does not return within the timeout after the cancellation.
+// The failure reports the stacks of the goroutines started by the runnable. It returns
+// the error of the runnable, which can return before the cancellation:
+//
+//	err := runnabletest.RequireStop(t, worker, 100*time.Millisecond, time.Second)
+//	require.ErrorIs(t, err, context.Canceled)
+//
+// A runnable that does not stop is left running.
+func RequireStop(t testing.TB, r Runnable, runFor, timeout time.Duration) error {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	id := fmt.Sprintf("%s#%d", t.Name(), stopCount.Add(1))
+
+	done := make(chan error, 1)
+	go pprof.Do(ctx, pprof.Labels(stopLabel, id), func(ctx context.Context) {
+		done <- r.Run(ctx)
+	})
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(runFor):
+	}
+
+	cancel()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+	}
+
+	t.Fatalf("runnable still running %s after cancellation\n\n%s", timeout, goroutines(id))
+	return nil
+}
+
+// goroutines returns the stacks of the goroutines labelled with the id.
+func goroutines(id string) string {
+	var buf bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, 1); err != nil {
+		return "failed to dump goroutines: " + err.Error()
+	}
+
+	// The profile lists a record per distinct stack, separated by blank lines.
+	label := fmt.Sprintf("%q:%q", stopLabel, id)
+	var out strings.Builder
+	for record := range strings.SplitSeq(buf.String(), "\n\n") {
+		_, labels, _ := strings.Cut(record, "# labels: ")
+		labels, _, _ = strings.Cut(labels, "\n")
+		if strings.Contains(labels, label) {
+			out.WriteString(record + "\n\n")
+		}
+	}
+	return out.String()
+}
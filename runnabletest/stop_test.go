@@ -0,0 +1,65 @@
+package runnabletest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type runnableFunc func(ctx context.Context) error
+
+func (f runnableFunc) Run(ctx context.Context) error { return f(ctx) }
+
+// fakeT records the failure instead of stopping the test.
+type fakeT struct {
+	testing.TB
+	failure string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Fatalf(format string, args ...any) {
+	t.failure = fmt.Sprintf(format, args...)
+}
+
+func ignoringRunnable(release <-chan struct{}) error {
+	<-release
+	return nil
+}
+
+func TestRequireStop(t *testing.T) {
+	t.Run("stops", func(t *testing.T) {
+		r := runnableFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		err := RequireStop(t, r, 10*time.Millisecond, time.Second)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("returns before the cancellation", func(t *testing.T) {
+		r := runnableFunc(func(ctx context.Context) error { return errors.New("boom") })
+
+		err := RequireStop(t, r, time.Second, time.Second)
+		require.EqualError(t, err, "boom")
+	})
+
+	t.Run("does not stop", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+
+		r := runnableFunc(func(ctx context.Context) error { return ignoringRunnable(release) })
+
+		ft := &fakeT{TB: t}
+		require.NoError(t, RequireStop(ft, r, 10*time.Millisecond, 10*time.Millisecond))
+
+		require.Contains(t, ft.failure, "runnable still running 10ms after cancellation")
+		require.Contains(t, ft.failure, `# labels: {"runnabletest":"TestRequireStop/does_not_stop#`)
+		require.Contains(t, ft.failure, "runnabletest.ignoringRunnable")
+	})
+}
@@ -2,44 +2,98 @@ package runnable
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"strconv"
+	"sync"
 	"time"
 )
 
 // ScheduleSpec computes the next execution time given the last execution start
 // and the current time. Interval specs like [Every] use lastStart to account
 // for execution time. Clock-aligned specs like [DailyAt] use now.
+// A zero time means the spec never fires again.
 type ScheduleSpec func(lastStart, now time.Time) time.Time
 
 // Schedule returns a runnable that runs the given runnable according to the
 // provided schedule specs. When multiple specs are provided, the runnable
 // runs at whichever fires next.
 //
-// If an execution outlasts the interval, missed ticks are skipped (not queued).
-// On error from the inner runnable, Schedule stops and returns the error.
-// On context cancellation, returns [context.Canceled].
+// Ticks that fire while an execution is still running are handled according to
+// the [OverlapPolicy] set with [schedule.Overlap]. By default, the next tick is planned
+// once the execution completed, so [Every] runs again right away ([OverlapWait]).
+// Panics are recovered and treated as errors.
 //
-// For custom scheduling logic, pass a [ScheduleSpec] function directly.
-// For example, to use github.com/robfig/cron/v3:
+// On error from the inner runnable, Schedule stops and returns the error, unless
+// configured with [schedule.ErrorLimit] to continue after failures. A failed run can
+// be retried before waiting for the next tick with [schedule.Retry].
+// On context cancellation, running executions are cancelled and awaited, and
+// Schedule returns [context.Canceled].
+//
+// A running schedule can be controlled with [schedule.Trigger], [schedule.Pause] and
+// [schedule.Resume], and inspected with [schedule.Next].
+//
+// Cron expressions are supported with [Cron]:
+//
+//	spec, err := Cron("15 */6 * * *") // every 6h at :15
+//	if err != nil {
+//	    return err
+//	}
+//	Schedule(worker, spec)
 //
-//	sched, _ := cron.ParseStandard("15 */6 * * *") // every 6h at :15
-//	Schedule(worker, func(_, now time.Time) time.Time {
-//	    return sched.Next(now)
-//	})
+// For custom scheduling logic, pass a [ScheduleSpec] function directly.
 func Schedule(runnable Runnable, specs ...ScheduleSpec) *schedule {
 	return &schedule{
-		name:     "schedule/" + runnableName(runnable),
-		runnable: runnable,
-		specs:    specs,
+		name:       "schedule/" + runnableName(runnable),
+		runnable:   runnable,
+		specs:      specs,
+		errorLimit: 1,
+		clock:      systemClock{},
+		triggers:   make(chan struct{}, 1),
+		changes:    make(chan struct{}, 1),
 	}
 }
 
 type schedule struct {
-	name     string
-	runnable Runnable
-	specs    []ScheduleSpec
+	name          string
+	runnable      Runnable
+	specs         []ScheduleSpec
+	overlap       OverlapPolicy
+	maxConcurrent int
+	onEvent       func(ScheduleEvent)
+	errorLimit    int
+	retries       int
+	retryBackoff  func(attempt int) time.Duration
+	runOnStart    bool
+	initialDelay  time.Duration
+	jitter        time.Duration
+	splay         time.Duration
+	timeout       time.Duration
+	store         ScheduleStore
+	catchUp       CatchUpPolicy
+	locker        Locker
+	lockTTL       time.Duration
+	clock         Clock
+
+	triggers chan struct{}
+	changes  chan struct{} // pause and resume
+
+	mu     sync.Mutex
+	paused bool
+	next   time.Time
+	locks  []heldLock // locks of the previous ticks, until they expire
+}
+
+// heldLock is the lock of a tick, kept until it expires.
+type heldLock struct {
+	key     string
+	expires time.Time
 }
 
 func (s *schedule) runnableName() string { return s.name }
+func (s *schedule) unwrap() Runnable     { return s.runnable }
 
 // Name sets the runnable name, used in log messages. Defaults to "schedule/<inner>".
 func (s *schedule) Name(name string) *schedule {
@@ -47,38 +101,633 @@ func (s *schedule) Name(name string) *schedule {
 	return s
 }
 
+// ErrorLimit sets the number of consecutive failed runs after which Schedule stops and
+// returns the last error. Failed runs below the limit are logged, and the schedule
+// continues. Defaults to 1 (stop on the first error). Zero means unlimited.
+func (s *schedule) ErrorLimit(n int) *schedule {
+	s.errorLimit = n
+	return s
+}
+
+// Retry retries a failed run up to the given number of attempts, before it counts as
+// a failed run. The backoff function receives the attempt number (starting at 1) and
+// returns the delay before that attempt. A nil backoff retries immediately.
+// Retries are part of the run: ticks that fire meanwhile are handled by the overlap policy.
+func (s *schedule) Retry(attempts int, backoff func(attempt int) time.Duration) *schedule {
+	s.retries = attempts
+	s.retryBackoff = backoff
+	return s
+}
+
+// Timeout sets the maximum duration of each run, including each retry. A run that
+// exceeds it has its context cancelled, and fails with a [*TimeoutError] that counts
+// toward the error limit. Zero means no timeout (the default).
+func (s *schedule) Timeout(d time.Duration) *schedule {
+	s.timeout = d
+	return s
+}
+
+// Store sets the store recording the last successful run, under the schedule name.
+// Schedules sharing a store must have distinct names (see [schedule.Name]).
+// Errors from the store are logged and do not stop the schedule.
+func (s *schedule) Store(store ScheduleStore) *schedule {
+	s.store = store
+	return s
+}
+
+// CatchUp sets what to do at startup with the ticks missed since the last successful
+// run recorded in the store. Defaults to [CatchUpSkip]. Requires [schedule.Store].
+func (s *schedule) CatchUp(policy CatchUpPolicy) *schedule {
+	s.catchUp = policy
+	return s
+}
+
+// Locker sets the lock acquired before running a tick, so that a single replica runs
+// each tick: ticks are skipped while their lock is held elsewhere. The lock is keyed by
+// the schedule name and the planned time of the tick, before jitter and splay, and is
+// kept until the ttl expired, so that the replicas firing the same tick later skip it.
+// The ttl must exceed the duration of a run, retries included, and the spread of the
+// replicas (see [schedule.Splay] and [schedule.Jitter]). Errors from the locker fail
+// the run.
+//
+// Replicas share the planned times of clock-aligned specs like [DailyAt] and [Cron].
+// Interval specs like [Every] count from the start of each replica, so that replicas
+// started at different times plan different ticks, and do not exclude each other.
+// Runs started by [schedule.Trigger] are planned at the time of the trigger, and are
+// not excluded across replicas either.
+func (s *schedule) Locker(locker Locker, ttl time.Duration) *schedule {
+	s.locker = locker
+	s.lockTTL = ttl
+	return s
+}
+
+// Clock sets the clock used to plan the ticks and measure the delays and timeouts.
+// Defaults to the system clock.
+func (s *schedule) Clock(clock Clock) *schedule {
+	s.clock = clock
+	return s
+}
+
+// RunOnStart runs the runnable as soon as the schedule starts (after the initial
+// delay, if any), instead of waiting for the first tick.
+func (s *schedule) RunOnStart() *schedule {
+	s.runOnStart = true
+	return s
+}
+
+// InitialDelay delays the start of the schedule. Interval specs like [Every] count
+// from the end of the delay.
+func (s *schedule) InitialDelay(d time.Duration) *schedule {
+	s.initialDelay = d
+	return s
+}
+
+// Jitter delays each tick by a random duration between zero and maxDelay, to spread the
+// load of replicas running the same schedule. The jitter does not accumulate:
+// interval specs count from the planned time of the previous tick.
+func (s *schedule) Jitter(maxDelay time.Duration) *schedule {
+	s.jitter = maxDelay
+	return s
+}
+
+// Splay delays each tick by a fixed duration between zero and maxDelay, derived from the
+// instance identifier (typically the hostname). Unlike [schedule.Jitter], each instance
+// always runs at the same offset.
+func (s *schedule) Splay(maxDelay time.Duration, instance string) *schedule {
+	s.splay = 0
+	if maxDelay > 0 {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(instance))
+		s.splay = time.Duration(h.Sum64() % uint64(maxDelay))
+	}
+	return s
+}
+
+// offset returns the delay added to the next planned tick.
+func (s *schedule) offset() time.Duration {
+	offset := s.splay
+	if s.jitter > 0 {
+		offset += rand.N(s.jitter)
+	}
+	return offset
+}
+
+// OverlapPolicy decides what [Schedule] does when a tick fires while a previous
+// execution is still running.
+type OverlapPolicy int
+
+const (
+	// OverlapWait waits for the running execution to complete, then plans the next
+	// tick from the time it completed: interval specs like [Every] fire right away, and
+	// clock-aligned specs like [DailyAt] fire at their next time. Ticks fired with
+	// [schedule.Trigger] are skipped. This is the default.
+	OverlapWait OverlapPolicy = iota
+	// OverlapSkip skips the tick, waiting for the next one.
+	OverlapSkip
+	// OverlapQueue runs once more when the running execution completes. Further ticks
+	// are skipped while a run is already queued.
+	OverlapQueue
+	// OverlapConcurrent starts another execution concurrently, up to the limit set
+	// with [schedule.MaxConcurrent]. Ticks beyond the limit are skipped.
+	OverlapConcurrent
+	// OverlapReplace cancels the running execution, and starts a new one as soon as
+	// it has returned. The error returned by the cancelled execution is ignored.
+	OverlapReplace
+)
+
+// Overlap sets the policy for ticks that fire while an execution is still running.
+// Defaults to [OverlapWait].
+func (s *schedule) Overlap(policy OverlapPolicy) *schedule {
+	s.overlap = policy
+	return s
+}
+
+// MaxConcurrent sets the maximum number of concurrent executions with
+// [OverlapConcurrent]. Zero means unlimited (the default).
+func (s *schedule) MaxConcurrent(n int) *schedule {
+	s.maxConcurrent = n
+	return s
+}
+
+// ScheduleEventKind is the kind of a [ScheduleEvent].
+type ScheduleEventKind string
+
+const (
+	// ScheduleSkipped reports a tick that was skipped because of the overlap policy,
+	// or because the lock is held elsewhere, with the event error [ErrLockHeld].
+	ScheduleSkipped ScheduleEventKind = "skipped"
+	// ScheduleQueued reports a tick that was queued with [OverlapQueue].
+	ScheduleQueued ScheduleEventKind = "queued"
+	// ScheduleCancelled reports an execution cancelled with [OverlapReplace].
+	ScheduleCancelled ScheduleEventKind = "cancelled"
+	// ScheduleFailed reports a failed run, after retries.
+	ScheduleFailed ScheduleEventKind = "failed"
+	// ScheduleTimedOut reports a run that exceeded the timeout set with [schedule.Timeout],
+	// after retries. The event error is a [*TimeoutError].
+	ScheduleTimedOut ScheduleEventKind = "timed out"
+)
+
+// ScheduleEvent describes a notable event of a [Schedule].
+type ScheduleEvent struct {
+	Kind ScheduleEventKind
+	Time time.Time
+	Err  error
+}
+
+// OnEvent sets a function called on notable events, like skipped ticks.
+// It is called synchronously from the scheduling loop and must not block.
+func (s *schedule) OnEvent(fn func(ScheduleEvent)) *schedule {
+	s.onEvent = fn
+	return s
+}
+
+func (s *schedule) emit(kind ScheduleEventKind, err error) {
+	if s.onEvent != nil {
+		s.onEvent(ScheduleEvent{Kind: kind, Time: s.clock.Now(), Err: err})
+	}
+}
+
 func (s *schedule) Run(ctx context.Context) error {
-	lastStart := time.Now()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-	for {
-		next := s.nextTime(lastStart, time.Now())
+	ex := &executions{schedule: s, ctx: ctx, done: make(chan execution)}
+	defer ex.wait()
+	defer s.plan(time.Time{}, 0)
+
+	// Drop a trigger made while the schedule was not running.
+	select {
+	case <-s.triggers:
+	default:
+	}
 
+	if s.initialDelay > 0 {
+		delay, stop := s.clock.NewTimer(s.initialDelay)
 		select {
 		case <-ctx.Done():
+			stop()
 			return ctx.Err()
-		case <-time.After(time.Until(next)):
-			lastStart = time.Now()
-			if err := s.runnable.Run(ctx); err != nil {
+		case <-delay:
+		}
+	}
+
+	lastStart := s.clock.Now()
+	ex.catchUp(lastStart)
+	if s.runOnStart && !s.isPaused() {
+		ex.tick(lastStart)
+	}
+
+	// The next tick is planned at next, and fires after the offset (jitter and splay).
+	next, offset := s.nextTime(lastStart, lastStart), s.offset()
+	tick, stopTick := s.plan(next, offset)
+	defer func() { stopTick() }()
+
+	// replan stops the timer of the planned tick, and plans the next one.
+	replan := func() {
+		stopTick()
+		next, offset = s.nextTime(lastStart, s.clock.Now()), s.offset()
+		tick, stopTick = s.plan(next, offset)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case result := <-ex.done:
+			if err := ex.completed(result); err != nil {
+				cancel()
 				return err
 			}
+			if ex.waiting && ex.running == 0 {
+				ex.waiting = false
+				replan()
+			}
+		case <-tick:
+			if s.overlap == OverlapWait && ex.running > 0 {
+				// Plan the next tick once the execution completed.
+				logger.Info(s.name+": tick delayed", "reason", "still running")
+				ex.waiting = true
+				tick = nil
+				continue
+			}
+			lastStart = next
+			ex.tick(lastStart)
+			replan()
+		case <-s.triggers:
+			logger.Info(s.name + ": triggered")
+			ex.tick(s.clock.Now())
+		case <-s.changes:
+			replan()
 		}
 	}
 }
 
-// nextTime returns the earliest next execution time across all specs.
+// plan records the next tick, and starts a timer firing after the offset past it. It
+// returns a nil channel when paused or when no spec fires again, to wait for
+// cancellation.
+func (s *schedule) plan(next time.Time, offset time.Duration) (<-chan time.Time, func() bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.paused {
+		next = time.Time{}
+	}
+	s.next = next
+
+	if next.IsZero() {
+		return nil, func() bool { return false }
+	}
+	return s.clock.NewTimer(next.Add(offset).Sub(s.clock.Now()))
+}
+
+// Trigger runs the runnable now, out of band, applying the overlap policy if an
+// execution is still running. It does not move the next planned tick, and works while
+// paused. It is safe for concurrent use, and does nothing if a trigger is already
+// pending or if the schedule is not running.
+func (s *schedule) Trigger() {
+	select {
+	case s.triggers <- struct{}{}:
+	default:
+	}
+}
+
+// Pause stops the ticks until [schedule.Resume] is called. Running executions are not
+// affected. A schedule paused before Run starts paused. It is safe for concurrent use.
+func (s *schedule) Pause() {
+	s.setPaused(true)
+}
+
+// Resume restarts the ticks stopped by [schedule.Pause]. The ticks missed while paused
+// are skipped, except that interval specs like [Every] fire right away when a full
+// interval elapsed. It is safe for concurrent use.
+func (s *schedule) Resume() {
+	s.setPaused(false)
+}
+
+func (s *schedule) setPaused(paused bool) {
+	s.mu.Lock()
+	changed := s.paused != paused
+	s.paused = paused
+	s.mu.Unlock()
+
+	if !changed {
+		return
+	}
+	if paused {
+		logger.Info(s.name + ": paused")
+	} else {
+		logger.Info(s.name + ": resumed")
+	}
+
+	select {
+	case s.changes <- struct{}{}:
+	default:
+	}
+}
+
+func (s *schedule) isPaused() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.paused
+}
+
+// Next returns the time of the next planned tick, before jitter and splay. It returns
+// the zero time when paused, when not running, or when no spec fires again.
+// It is safe for concurrent use.
+func (s *schedule) Next() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.next
+}
+
+// executions tracks the executions started by a running schedule.
+type executions struct {
+	schedule   *schedule
+	ctx        context.Context
+	done       chan execution
+	running    int
+	queued     bool
+	queuedTick time.Time
+	waiting    bool               // a tick fired while running, with OverlapWait
+	backlog    []time.Time        // missed ticks to catch up
+	cancel     context.CancelFunc // cancels the latest execution
+	failures   int                // consecutive failed runs
+	recorded   time.Time          // last successful tick recorded in the store
+}
+
+// execution is the result of an execution.
+type execution struct {
+	tick      time.Time // planned time of the tick that started the execution
+	err       error
+	cancelled bool // cancelled by the overlap policy
+}
+
+// catchUp starts the runs for the ticks missed since the last successful run in the store.
+func (ex *executions) catchUp(now time.Time) {
+	s := ex.schedule
+	if s.store == nil {
+		return
+	}
+
+	last, err := s.store.LastRun(ex.ctx, s.name)
+	if err != nil {
+		logger.Info(s.name+": failed to read last run", "error", err)
+		return
+	}
+	ex.recorded = last
+	if last.IsZero() || s.catchUp == CatchUpSkip {
+		return
+	}
+
+	first := s.nextTime(last, last)
+	if first.IsZero() || first.After(now) {
+		return
+	}
+
+	if s.catchUp == CatchUpOnce {
+		logger.Info(s.name+": catching up", "policy", "once", "last", last)
+		ex.start(now)
+		return
+	}
+
+	var missed []time.Time
+	for t := first; !t.IsZero() && !t.After(now); t = s.nextTime(t, t) {
+		missed = append(missed, t)
+		if len(missed) > MaxCatchUp {
+			missed = missed[1:]
+		}
+	}
+	logger.Info(s.name+": catching up", "policy", "all", "missed", len(missed), "last", last)
+
+	ex.start(missed[0])
+	ex.backlog = missed[1:]
+}
+
+// tick starts an execution, or applies the overlap policy if one is running.
+func (ex *executions) tick(tick time.Time) {
+	s := ex.schedule
+
+	if ex.running == 0 {
+		ex.start(tick)
+		return
+	}
+
+	switch s.overlap {
+	case OverlapQueue:
+		if !ex.queued {
+			ex.queued = true
+			ex.queuedTick = tick
+			logger.Info(s.name+": tick queued", "reason", "still running")
+			s.emit(ScheduleQueued, nil)
+			return
+		}
+	case OverlapConcurrent:
+		if s.maxConcurrent == 0 || ex.running < s.maxConcurrent {
+			ex.start(tick)
+			return
+		}
+	case OverlapReplace:
+		if !ex.queued {
+			ex.queued = true
+			ex.queuedTick = tick
+			logger.Info(s.name+": cancelling running execution", "reason", "replaced by new tick")
+			ex.cancel()
+			s.emit(ScheduleCancelled, nil)
+			return
+		}
+	case OverlapWait, OverlapSkip:
+	}
+
+	logger.Info(s.name+": tick skipped", "reason", "still running", "running", ex.running)
+	s.emit(ScheduleSkipped, nil)
+}
+
+// start runs an execution in a goroutine. Panics are recovered and returned as errors,
+// since they can no longer reach the caller of Run.
+func (ex *executions) start(tick time.Time) {
+	ctx, cancel := context.WithCancel(ex.ctx)
+	ex.cancel = cancel
+	ex.running++
+
+	go func() {
+		defer cancel()
+		err := ex.schedule.execute(ctx, tick)
+		ex.done <- execution{tick, err, ctx.Err() != nil && ex.ctx.Err() == nil}
+	}()
+}
+
+// execute runs the runnable while holding the lock, with retries.
+func (s *schedule) execute(ctx context.Context, tick time.Time) error {
+	if s.locker != nil {
+		if err := s.lock(ctx, tick); err != nil {
+			return err
+		}
+	}
+
+	err := s.runOnce(ctx)
+
+	for attempt := 1; err != nil && attempt <= s.retries && ctx.Err() == nil; attempt++ {
+		var delay time.Duration
+		if s.retryBackoff != nil {
+			delay = s.retryBackoff(attempt)
+		}
+		logger.Info(s.name+": retrying", "attempt", attempt, "delay", delay, "error", err)
+
+		backoff, stop := s.clock.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			stop()
+			return err
+		case <-backoff:
+		}
+
+		err = s.runOnce(ctx)
+	}
+
+	return err
+}
+
+// lock acquires the lock of the tick, kept until it expires. It releases the locks of
+// the previous ticks that expired, so that they do not pile up in the locker.
+func (s *schedule) lock(ctx context.Context, tick time.Time) error {
+	now := s.clock.Now()
+
+	s.mu.Lock()
+	var expired []heldLock
+	held := s.locks[:0]
+	for _, l := range s.locks {
+		if l.expires.After(now) {
+			held = append(held, l)
+		} else {
+			expired = append(expired, l)
+		}
+	}
+	s.locks = held
+	s.mu.Unlock()
+
+	for _, l := range expired {
+		if err := s.locker.Unlock(ctx, l.key); err != nil {
+			logger.Info(s.name+": failed to release expired lock", "key", l.key, "error", err)
+		}
+	}
+
+	key := s.name + "@" + strconv.FormatInt(tick.UnixNano(), 10)
+	locked, err := s.locker.TryLock(ctx, key, s.lockTTL)
+	if err != nil {
+		return fmt.Errorf("%s: lock: %w", s.name, err)
+	}
+	if !locked {
+		return ErrLockHeld
+	}
+
+	s.mu.Lock()
+	s.locks = append(s.locks, heldLock{key, now.Add(s.lockTTL)})
+	s.mu.Unlock()
+	return nil
+}
+
+// runOnce runs the runnable once, within the timeout.
+func (s *schedule) runOnce(ctx context.Context) error {
+	if s.timeout <= 0 {
+		return Recover(s.runnable).Run(ctx)
+	}
+
+	runCtx, cancel := withTimeout(ctx, s.clock, s.timeout)
+	defer cancel()
+
+	err := Recover(s.runnable).Run(runCtx)
+	if err != nil && ctx.Err() == nil && errors.Is(context.Cause(runCtx), context.DeadlineExceeded) {
+		return &TimeoutError{s.name, s.timeout}
+	}
+	return err
+}
+
+// completed records a completed execution, starts the queued one, and returns the
+// error that must stop the schedule, if any.
+func (ex *executions) completed(result execution) error {
+	ex.running--
+	s := ex.schedule
+
+	switch {
+	case result.cancelled:
+		// Replaced by the overlap policy: not a failure.
+	case errors.Is(result.err, ErrLockHeld):
+		logger.Info(s.name+": tick skipped", "reason", "lock held elsewhere")
+		s.emit(ScheduleSkipped, ErrLockHeld)
+	case result.err != nil:
+		ex.failures++
+		kind := ScheduleFailed
+		if timeoutErr := (*TimeoutError)(nil); errors.As(result.err, &timeoutErr) {
+			kind = ScheduleTimedOut
+		}
+		s.emit(kind, result.err)
+
+		if s.errorLimit > 0 && ex.failures >= s.errorLimit {
+			if s.errorLimit > 1 {
+				logger.Info(s.name+": stopping", "reason", "error limit", "limit", s.errorLimit)
+			}
+			return result.err
+		}
+		logger.Info(s.name+": run "+string(kind), "error", result.err, "errors", ex.failures)
+	default:
+		ex.failures = 0
+		ex.record(result.tick)
+	}
+
+	switch {
+	case ex.running > 0:
+	case len(ex.backlog) > 0:
+		ex.start(ex.backlog[0])
+		ex.backlog = ex.backlog[1:]
+	case ex.queued:
+		ex.queued = false
+		ex.start(ex.queuedTick)
+	}
+	return nil
+}
+
+// record saves the tick of a successful run in the store.
+func (ex *executions) record(tick time.Time) {
+	s := ex.schedule
+	if s.store == nil || !tick.After(ex.recorded) {
+		return
+	}
+	ex.recorded = tick
+
+	// Record runs completing during shutdown too.
+	if err := s.store.SetLastRun(context.WithoutCancel(ex.ctx), s.name, tick); err != nil {
+		logger.Info(s.name+": failed to record last run", "error", err)
+	}
+}
+
+// wait waits for all running executions to return.
+func (ex *executions) wait() {
+	for ex.running > 0 {
+		result := <-ex.done
+		ex.running--
+		if result.err == nil {
+			ex.record(result.tick)
+		}
+	}
+}
+
+// nextTime returns the earliest next execution time across all specs, or the zero
+// time if no spec fires again.
 func (s *schedule) nextTime(lastStart, now time.Time) time.Time {
-	earliest := s.specs[0](lastStart, now)
-	for _, spec := range s.specs[1:] {
-		if t := spec(lastStart, now); t.Before(earliest) {
+	var earliest time.Time
+	for _, spec := range s.specs {
+		if t := spec(lastStart, now); !t.IsZero() && (earliest.IsZero() || t.Before(earliest)) {
 			earliest = t
 		}
 	}
 	return earliest
 }
 
-// Every returns a schedule spec that triggers at regular intervals, accounting
-// for execution time. If the runnable takes longer than the interval, the next
-// execution starts immediately (missed ticks are skipped, not queued).
+// Every returns a schedule spec that triggers at regular intervals from the last
+// start, so the execution time does not delay the next tick. If the next tick is
+// already past, it triggers immediately: by default, when the runnable takes longer
+// than the interval, the next execution starts as soon as it completed (missed ticks
+// are skipped, not queued). See [OverlapPolicy] for other behaviors.
 func Every(d time.Duration) ScheduleSpec {
 	return func(lastStart, now time.Time) time.Time {
 		next := lastStart.Add(d)
@@ -95,23 +744,230 @@ func Hourly() ScheduleSpec {
 }
 
 // HourlyAt returns a schedule spec that triggers at the given minute past each hour.
+// Like [DailyAt], it follows the wall clock in the location of the current time.
 func HourlyAt(minute int) ScheduleSpec {
 	return func(_, now time.Time) time.Time {
-		next := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), minute, 0, 0, now.Location())
+		next := wallTime(now.Year(), now.Month(), now.Day(), now.Hour(), minute, 0, now.Location())
 		if !next.After(now) {
-			next = next.Add(time.Hour)
+			next = wallTime(now.Year(), now.Month(), now.Day(), now.Hour()+1, minute, 0, now.Location())
 		}
 		return next
 	}
 }
 
 // DailyAt returns a schedule spec that triggers at the given hour and minute each day.
+//
+// The wall clock is evaluated in the location of the current time, which is
+// [time.Local] unless changed with [In]. Across DST transitions, the spec triggers
+// once per day: a time skipped by the transition triggers at the first valid instant
+// after it, and a repeated time triggers at its first occurrence.
 func DailyAt(hour, minute int) ScheduleSpec {
 	return func(_, now time.Time) time.Time {
-		next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+		next := wallTime(now.Year(), now.Month(), now.Day(), hour, minute, 0, now.Location())
 		if !next.After(now) {
-			next = next.AddDate(0, 0, 1)
+			next = wallTime(now.Year(), now.Month(), now.Day()+1, hour, minute, 0, now.Location())
 		}
 		return next
 	}
 }
+
+// WeekdaysAt returns a schedule spec that triggers at the given hour and minute from
+// Monday to Friday.
+func WeekdaysAt(hour, minute int) ScheduleSpec {
+	return calendarAt(hour, minute, func(date time.Time) bool {
+		return date.Weekday() != time.Saturday && date.Weekday() != time.Sunday
+	})
+}
+
+// WeeklyAt returns a schedule spec that triggers at the given hour and minute on the
+// given day of the week.
+func WeeklyAt(weekday time.Weekday, hour, minute int) ScheduleSpec {
+	return calendarAt(hour, minute, func(date time.Time) bool {
+		return date.Weekday() == weekday
+	})
+}
+
+// MonthlyAt returns a schedule spec that triggers at the given hour and minute on the
+// given day of the month. In months with fewer days, it triggers on the last day of
+// the month, so MonthlyAt(31, h, m) triggers on the last day of every month.
+func MonthlyAt(day, hour, minute int) ScheduleSpec {
+	return calendarAt(hour, minute, func(date time.Time) bool {
+		return date.Day() == min(day, daysInMonth(date))
+	})
+}
+
+// MonthlyOnWeekday returns a schedule spec that triggers at the given hour and minute
+// on the nth occurrence of a day of the week in each month: 1 for the first, 2 for the
+// second, and so on. Negative values count from the end of the month: -1 for the last.
+// Months without an nth occurrence are skipped.
+//
+// For example, MonthlyOnWeekday(1, time.Monday, 9, 0) triggers on the first Monday of
+// every month at 9:00.
+func MonthlyOnWeekday(n int, weekday time.Weekday, hour, minute int) ScheduleSpec {
+	return calendarAt(hour, minute, func(date time.Time) bool {
+		if date.Weekday() != weekday {
+			return false
+		}
+		if n > 0 {
+			return (date.Day()-1)/7+1 == n
+		}
+		return (daysInMonth(date)-date.Day())/7+1 == -n
+	})
+}
+
+// calendarSearchLimit bounds the search for the next matching day, for calendar specs
+// that never match (like the sixth Monday of the month).
+const calendarSearchLimit = 5 * 366
+
+// calendarAt returns a schedule spec that triggers at the given wall clock time on the
+// days accepted by match. The dates passed to match are calendar dates in UTC.
+func calendarAt(hour, minute int, match func(date time.Time) bool) ScheduleSpec {
+	return func(_, now time.Time) time.Time {
+		date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		for range calendarSearchLimit {
+			if match(date) {
+				next := wallTime(date.Year(), date.Month(), date.Day(), hour, minute, 0, now.Location())
+				if next.After(now) {
+					return next
+				}
+			}
+			date = date.AddDate(0, 0, 1)
+		}
+		return time.Time{}
+	}
+}
+
+func daysInMonth(date time.Time) int {
+	return time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// Window reports whether a time falls in an exclusion window, and when the window
+// ends. See [Except]. A window that cannot tell its end returns the zero time: the
+// excluded times are then skipped one by one.
+type Window func(t time.Time) (end time.Time, in bool)
+
+// Between returns a window from start (inclusive) to end (exclusive).
+func Between(start, end time.Time) Window {
+	return func(t time.Time) (time.Time, bool) {
+		return end, !t.Before(start) && t.Before(end)
+	}
+}
+
+// DailyBetween returns a window recurring every day, from the start wall clock time
+// (inclusive) to the end wall clock time (exclusive), in the location of the time being
+// checked. The window spans midnight when the end is before the start.
+func DailyBetween(startHour, startMinute, endHour, endMinute int) Window {
+	start := startHour*60 + startMinute
+	end := endHour*60 + endMinute
+	return func(t time.Time) (time.Time, bool) {
+		m := t.Hour()*60 + t.Minute()
+		day := t.Day()
+		switch {
+		case start <= end && m >= start && m < end:
+		case start > end && m >= start:
+			day++ // ends tomorrow
+		case start > end && m < end:
+		default:
+			return time.Time{}, false
+		}
+		return wallTime(t.Year(), t.Month(), day, endHour, endMinute, 0, t.Location()), true
+	}
+}
+
+// exceptSearchLimit bounds the number of windows skipped by [Except].
+const exceptSearchLimit = 100_000
+
+// Except returns a schedule spec that skips the times of the given spec that fall in
+// any of the windows, for example to avoid a maintenance blackout:
+//
+//	Except(Every(time.Minute), DailyBetween(2, 0, 4, 0))
+//
+// A time in a window is replaced by the first time of the spec from the end of the
+// window, so [Every] fires when the window ends. The spec never fires again, and logs
+// it, if no time outside the windows is found after skipping a large number of them.
+func Except(spec ScheduleSpec, windows ...Window) ScheduleSpec {
+	// excluded returns the latest end of the windows containing t, if any.
+	excluded := func(t time.Time) (time.Time, bool) {
+		var end time.Time
+		found := false
+		for _, w := range windows {
+			if wEnd, in := w(t); in {
+				found = true
+				if wEnd.After(end) {
+					end = wEnd
+				}
+			}
+		}
+		return end, found
+	}
+
+	return func(lastStart, now time.Time) time.Time {
+		next := spec(lastStart, now)
+		for range exceptSearchLimit {
+			if next.IsZero() {
+				return next
+			}
+			end, in := excluded(next)
+			switch {
+			case !in:
+				return next
+			case end.After(next):
+				// Jump past the window, firing at its end if the spec is already due.
+				next = spec(next, end.Add(-time.Nanosecond))
+				if !next.IsZero() && next.Before(end) {
+					next = end
+				}
+			default:
+				next = spec(next, next)
+			}
+		}
+
+		logger.Info("schedule: no time found outside the exclusion windows, the spec never fires again", "after", now)
+		return time.Time{}
+	}
+}
+
+// In returns a schedule spec that evaluates the given spec in the given location,
+// instead of the location of the current time. For example, to run at 3:00 in Paris
+// regardless of the process time zone:
+//
+//	paris, _ := time.LoadLocation("Europe/Paris")
+//	Schedule(worker, In(paris, DailyAt(3, 0)))
+func In(loc *time.Location, spec ScheduleSpec) ScheduleSpec {
+	return func(lastStart, now time.Time) time.Time {
+		return spec(lastStart.In(loc), now.In(loc))
+	}
+}
+
+// wallTime returns the instant of a wall clock time in loc, normalizing out of range
+// values like [time.Date]. Unlike [time.Date], a wall clock time skipped by a DST
+// transition resolves to the first valid instant after it (the transition itself),
+// and a repeated wall clock time resolves to its first occurrence.
+func wallTime(year int, month time.Month, day, hour, minute, second int, loc *time.Location) time.Time {
+	t := time.Date(year, month, day, hour, minute, second, 0, loc)
+
+	want := time.Date(year, month, day, hour, minute, second, 0, time.UTC)
+	got := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	start, end := t.ZoneBounds()
+
+	if !got.Equal(want) {
+		// Skipped by a DST transition: time.Date picked an instant on either side of the gap.
+		if got.Before(want) {
+			return end
+		}
+		return start
+	}
+
+	// Repeated by a DST transition: the first occurrence is in the previous zone.
+	if !start.IsZero() {
+		_, offset := t.Zone()
+		_, prevOffset := start.Add(-time.Second).Zone()
+		if prevOffset > offset {
+			first := t.Add(-time.Duration(prevOffset-offset) * time.Second)
+			if first.Before(start) {
+				return first
+			}
+		}
+	}
+	return t
+}
@@ -0,0 +1,105 @@
+package runnable
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ScheduleStore persists the time of the last successful run of schedules, by name.
+// See [schedule.Store].
+type ScheduleStore interface {
+	// LastRun returns the time of the last successful run of the named schedule,
+	// or the zero time if there is none.
+	LastRun(ctx context.Context, name string) (time.Time, error)
+	// SetLastRun records the time of the last successful run of the named schedule.
+	SetLastRun(ctx context.Context, name string, t time.Time) error
+}
+
+// CatchUpPolicy decides what [Schedule] does at startup with the ticks missed while
+// the process was not running, according to its [ScheduleStore].
+type CatchUpPolicy int
+
+const (
+	// CatchUpSkip ignores the missed ticks (the default).
+	CatchUpSkip CatchUpPolicy = iota
+	// CatchUpOnce runs once at startup if any tick was missed.
+	CatchUpOnce
+	// CatchUpAll runs once at startup for each missed tick, one after the other,
+	// up to [MaxCatchUp] runs.
+	CatchUpAll
+)
+
+// MaxCatchUp is the maximum number of missed ticks run with [CatchUpAll].
+const MaxCatchUp = 1000
+
+// FileStore returns a [ScheduleStore] that keeps the last run times in a JSON file.
+// The file is created on the first write. It is safe for concurrent use by the
+// schedules of a process, but not across processes.
+func FileStore(path string) ScheduleStore {
+	return &fileStore{path: path}
+}
+
+type fileStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+func (f *fileStore) LastRun(_ context.Context, name string) (time.Time, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	runs, err := f.read()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return runs[name], nil
+}
+
+func (f *fileStore) SetLastRun(_ context.Context, name string, t time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	runs, err := f.read()
+	if err != nil {
+		return err
+	}
+	runs[name] = t
+
+	data, err := json.MarshalIndent(runs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("schedule store: %w", err)
+	}
+
+	// Write to a temporary file first, so that a crash never leaves a truncated file.
+	err = writeTempFile(filepath.Dir(f.path), filepath.Base(f.path)+".*", data, func(tmp string) error {
+		return os.Rename(tmp, f.path)
+	})
+	if err != nil {
+		return fmt.Errorf("schedule store: %w", err)
+	}
+	return nil
+}
+
+func (f *fileStore) read() (map[string]time.Time, error) {
+	runs := map[string]time.Time{}
+
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return runs, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("schedule store: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &runs); err != nil {
+		return nil, fmt.Errorf("schedule store: %s: %w", f.path, err)
+	}
+	return runs, nil
+}
@@ -0,0 +1,137 @@
+package runnable
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "schedules.json")
+
+	store := FileStore(path)
+
+	last, err := store.LastRun(ctx, "report")
+	require.NoError(t, err)
+	require.True(t, last.IsZero())
+
+	at := time.Date(2025, 1, 1, 3, 0, 0, 0, time.UTC)
+	require.NoError(t, store.SetLastRun(ctx, "report", at))
+	require.NoError(t, store.SetLastRun(ctx, "cleanup", at.Add(time.Hour)))
+
+	// A new store reads the same file.
+	store = FileStore(path)
+
+	last, err = store.LastRun(ctx, "report")
+	require.NoError(t, err)
+	require.True(t, at.Equal(last))
+
+	last, err = store.LastRun(ctx, "cleanup")
+	require.NoError(t, err)
+	require.True(t, at.Add(time.Hour).Equal(last))
+
+	// Corrupted file.
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	_, err = store.LastRun(ctx, "report")
+	require.ErrorContains(t, err, "schedule store: "+path)
+}
+
+// memoryStore is a ScheduleStore for tests.
+type memoryStore map[string]time.Time
+
+func (m memoryStore) LastRun(_ context.Context, name string) (time.Time, error) {
+	return m[name], nil
+}
+
+func (m memoryStore) SetLastRun(_ context.Context, name string, t time.Time) error {
+	m[name] = t
+	return nil
+}
+
+func TestSchedule_Store(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		store := memoryStore{}
+
+		var count int
+		worker := Func(func(ctx context.Context) error {
+			count++
+			if count == 2 {
+				return &dummyError{message: "task failed"}
+			}
+			return nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Hour+time.Minute)
+		defer cancel()
+
+		start := time.Now()
+		err := Schedule(worker, Every(time.Hour)).Name("job").Store(store).ErrorLimit(0).Run(ctx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		// The second run failed: the first one is the last successful run.
+		require.Equal(t, 2, count)
+		require.Equal(t, start.Add(time.Hour), store["job"])
+	})
+}
+
+func TestSchedule_CatchUp(t *testing.T) {
+	run := func(t *testing.T, policy CatchUpPolicy, last time.Time) ([]time.Time, memoryStore) {
+		t.Helper()
+
+		store := memoryStore{"job": last}
+
+		var starts []time.Time
+		worker := Func(func(ctx context.Context) error {
+			starts = append(starts, time.Now())
+			return nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		s := Schedule(worker, DailyAt(3, 0)).Name("job").Store(store).CatchUp(policy)
+		require.ErrorIs(t, s.Run(ctx), context.DeadlineExceeded)
+		return starts, store
+	}
+
+	// The synctest clock starts at 2000-01-01 00:00 UTC.
+	t.Run("skip", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			starts, _ := run(t, CatchUpSkip, time.Now().Add(-48*time.Hour))
+			require.Empty(t, starts)
+		})
+	})
+
+	t.Run("nothing missed", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			starts, _ := run(t, CatchUpAll, time.Now().Add(-time.Hour))
+			require.Empty(t, starts)
+		})
+	})
+
+	t.Run("once", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			now := time.Now()
+			starts, store := run(t, CatchUpOnce, now.Add(-48*time.Hour))
+			require.Equal(t, []time.Time{now}, starts)
+			require.Equal(t, now, store["job"])
+		})
+	})
+
+	t.Run("all", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			now := time.Now()
+			starts, store := run(t, CatchUpAll, now.Add(-48*time.Hour))
+
+			// Missed 03:00 on each of the two previous days.
+			require.Len(t, starts, 2)
+			require.Equal(t, time.Date(1999, 12, 31, 3, 0, 0, 0, time.Local), store["job"])
+		})
+	})
+}
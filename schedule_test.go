@@ -2,12 +2,17 @@ package runnable
 
 import (
 	"context"
+	"slices"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"testing/synctest"
 	"time"
+	_ "time/tzdata" // time zones for the DST tests
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/pior/runnable/runnabletest"
 )
 
 func TestScheduleSpec_Every(t *testing.T) {
@@ -88,6 +93,259 @@ func TestScheduleSpec_Hourly(t *testing.T) {
 	require.Equal(t, want.String(), got.String())
 }
 
+func TestScheduleSpec_Calendar(t *testing.T) {
+	// 2025-01-01 is a Wednesday.
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("weekdays", func(t *testing.T) {
+		require.Equal(t, []string{
+			"2025-01-02 09:00 UTC",
+			"2025-01-03 09:00 UTC",
+			"2025-01-06 09:00 UTC",
+		}, runSpec(WeekdaysAt(9, 0), start, 3))
+	})
+
+	t.Run("weekly", func(t *testing.T) {
+		require.Equal(t, []string{
+			"2025-01-06 09:30 UTC",
+			"2025-01-13 09:30 UTC",
+		}, runSpec(WeeklyAt(time.Monday, 9, 30), start, 2))
+
+		require.Equal(t, []string{
+			"2025-01-01 18:00 UTC",
+			"2025-01-08 18:00 UTC",
+		}, runSpec(WeeklyAt(time.Wednesday, 18, 0), start, 2))
+	})
+
+	t.Run("monthly", func(t *testing.T) {
+		require.Equal(t, []string{
+			"2025-01-15 00:00 UTC",
+			"2025-02-15 00:00 UTC",
+		}, runSpec(MonthlyAt(15, 0, 0), start, 2))
+	})
+
+	t.Run("monthly last day", func(t *testing.T) {
+		require.Equal(t, []string{
+			"2025-01-31 23:00 UTC",
+			"2025-02-28 23:00 UTC",
+			"2025-03-31 23:00 UTC",
+			"2025-04-30 23:00 UTC",
+		}, runSpec(MonthlyAt(31, 23, 0), start, 4))
+	})
+
+	t.Run("first monday of the month", func(t *testing.T) {
+		require.Equal(t, []string{
+			"2025-01-06 09:00 UTC",
+			"2025-02-03 09:00 UTC",
+			"2025-03-03 09:00 UTC",
+		}, runSpec(MonthlyOnWeekday(1, time.Monday, 9, 0), start, 3))
+	})
+
+	t.Run("last friday of the month", func(t *testing.T) {
+		require.Equal(t, []string{
+			"2025-01-31 17:00 UTC",
+			"2025-02-28 17:00 UTC",
+			"2025-03-28 17:00 UTC",
+		}, runSpec(MonthlyOnWeekday(-1, time.Friday, 17, 0), start, 3))
+	})
+
+	t.Run("fifth thursday skips months without one", func(t *testing.T) {
+		require.Equal(t, []string{
+			"2025-01-30 09:00 UTC",
+			"2025-05-29 09:00 UTC",
+		}, runSpec(MonthlyOnWeekday(5, time.Thursday, 9, 0), start, 2))
+	})
+
+	t.Run("never", func(t *testing.T) {
+		require.True(t, MonthlyOnWeekday(6, time.Monday, 9, 0)(start, start).IsZero())
+	})
+}
+
+func TestScheduleSpec_Except(t *testing.T) {
+	start := time.Date(2025, 1, 1, 1, 30, 0, 0, time.UTC)
+
+	t.Run("daily window", func(t *testing.T) {
+		spec := Except(Every(30*time.Minute), DailyBetween(2, 0, 4, 0))
+		require.Equal(t, []string{
+			"2025-01-01 04:00 UTC",
+			"2025-01-01 04:30 UTC",
+		}, runSpec(spec, start, 2))
+	})
+
+	t.Run("window spanning midnight", func(t *testing.T) {
+		spec := Except(HourlyAt(0), DailyBetween(23, 0, 2, 0))
+		require.Equal(t, []string{
+			"2025-01-01 02:00 UTC",
+			"2025-01-01 03:00 UTC",
+		}, runSpec(spec, start, 2))
+
+		require.Equal(t, []string{
+			"2025-01-01 22:00 UTC",
+			"2025-01-02 02:00 UTC",
+		}, runSpec(spec, start.Add(20*time.Hour), 2))
+	})
+
+	t.Run("one-time window", func(t *testing.T) {
+		blackout := Between(
+			time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+			time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC),
+		)
+		spec := Except(DailyAt(8, 0), blackout)
+		require.Equal(t, []string{
+			"2025-01-01 08:00 UTC",
+			"2025-01-04 08:00 UTC",
+		}, runSpec(spec, start, 2))
+	})
+
+	t.Run("long window over a short interval", func(t *testing.T) {
+		weekend := Between(
+			time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC),
+			time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC),
+		)
+		spec := Except(Every(time.Second), weekend)
+
+		friday := time.Date(2025, 1, 3, 23, 59, 59, 0, time.UTC)
+		require.Equal(t, time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), spec(friday, friday))
+
+		spec = Except(Every(time.Second), DailyBetween(20, 0, 8, 0))
+		evening := time.Date(2025, 1, 1, 19, 59, 59, 0, time.UTC)
+		require.Equal(t, time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC), spec(evening, evening))
+	})
+
+	t.Run("window ending on a tick", func(t *testing.T) {
+		blackout := Between(
+			time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+			time.Date(2025, 1, 3, 8, 0, 0, 0, time.UTC),
+		)
+		spec := Except(DailyAt(8, 0), blackout)
+		require.Equal(t, []string{
+			"2025-01-01 08:00 UTC",
+			"2025-01-03 08:00 UTC",
+		}, runSpec(spec, start, 2))
+	})
+
+	t.Run("always excluded", func(t *testing.T) {
+		spec := Except(HourlyAt(0), func(time.Time) (time.Time, bool) { return time.Time{}, true })
+		require.True(t, spec(start, start).IsZero())
+	})
+
+	t.Run("multiple specs", func(t *testing.T) {
+		s := Schedule(newDummyRunnable(),
+			Except(DailyAt(3, 0), DailyBetween(2, 0, 4, 0)),
+			WeeklyAt(time.Wednesday, 5, 0),
+		)
+		got := s.nextTime(start, start)
+		require.Equal(t, time.Date(2025, 1, 1, 5, 0, 0, 0, time.UTC).String(), got.String())
+	})
+}
+
+func TestScheduleSpec_In(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	spec := In(tokyo, DailyAt(8, 0))
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) // 09:00 in Tokyo
+
+	got := spec(time.Time{}, now)
+	want := time.Date(2025, 1, 2, 8, 0, 0, 0, tokyo)
+
+	require.True(t, want.Equal(got), "got %s", got)
+}
+
+// runSpec returns the successive times a spec fires from start, as the scheduler would.
+func runSpec(spec ScheduleSpec, start time.Time, n int) []string {
+	var times []string
+	now := start
+	for range n {
+		now = spec(now, now)
+		times = append(times, now.Format("2006-01-02 15:04 MST"))
+	}
+	return times
+}
+
+func TestScheduleSpec_DST(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	require.NoError(t, err)
+
+	t.Run("daily skipped time runs at the transition", func(t *testing.T) {
+		// 2025-03-09 02:00 EST jumps to 03:00 EDT.
+		start := time.Date(2025, 3, 8, 12, 0, 0, 0, newYork)
+		require.Equal(t, []string{
+			"2025-03-09 03:00 EDT",
+			"2025-03-10 02:30 EDT",
+		}, runSpec(In(newYork, DailyAt(2, 30)), start, 2))
+	})
+
+	t.Run("daily repeated time runs once", func(t *testing.T) {
+		// 2025-11-02 02:00 EDT falls back to 01:00 EST.
+		start := time.Date(2025, 11, 1, 12, 0, 0, 0, newYork)
+		require.Equal(t, []string{
+			"2025-11-02 01:30 EDT",
+			"2025-11-03 01:30 EST",
+		}, runSpec(In(newYork, DailyAt(1, 30)), start, 2))
+	})
+
+	t.Run("daily repeated time runs once in Paris", func(t *testing.T) {
+		// 2025-10-26 03:00 CEST falls back to 02:00 CET.
+		start := time.Date(2025, 10, 25, 12, 0, 0, 0, paris)
+		require.Equal(t, []string{
+			"2025-10-26 02:30 CEST",
+			"2025-10-27 02:30 CET",
+		}, runSpec(In(paris, DailyAt(2, 30)), start, 2))
+	})
+
+	t.Run("daily repeated time runs once in Sydney", func(t *testing.T) {
+		// 2025-04-06 03:00 AEDT falls back to 02:00 AEST.
+		start := time.Date(2025, 4, 5, 12, 0, 0, 0, sydney)
+		require.Equal(t, []string{
+			"2025-04-06 02:30 AEDT",
+			"2025-04-07 02:30 AEST",
+		}, runSpec(In(sydney, DailyAt(2, 30)), start, 2))
+	})
+
+	t.Run("hourly across spring forward", func(t *testing.T) {
+		start := time.Date(2025, 3, 9, 0, 45, 0, 0, newYork)
+		require.Equal(t, []string{
+			"2025-03-09 01:30 EST",
+			"2025-03-09 03:00 EDT", // 02:30 does not exist
+			"2025-03-09 03:30 EDT",
+		}, runSpec(In(newYork, HourlyAt(30)), start, 3))
+	})
+
+	t.Run("hourly across fall back", func(t *testing.T) {
+		start := time.Date(2025, 11, 2, 0, 45, 0, 0, newYork)
+		require.Equal(t, []string{
+			"2025-11-02 01:30 EDT",
+			"2025-11-02 02:30 EST", // 01:30 EST is a repeated wall clock time
+			"2025-11-02 03:30 EST",
+		}, runSpec(In(newYork, HourlyAt(30)), start, 3))
+	})
+
+	t.Run("cron", func(t *testing.T) {
+		spec, err := Cron("30 1,2 * * *")
+		require.NoError(t, err)
+
+		start := time.Date(2025, 3, 9, 0, 0, 0, 0, newYork)
+		require.Equal(t, []string{
+			"2025-03-09 01:30 EST",
+			"2025-03-09 03:00 EDT",
+			"2025-03-10 01:30 EDT",
+		}, runSpec(In(newYork, spec), start, 3))
+
+		start = time.Date(2025, 11, 2, 0, 0, 0, 0, newYork)
+		require.Equal(t, []string{
+			"2025-11-02 01:30 EDT",
+			"2025-11-02 02:30 EST",
+			"2025-11-03 01:30 EST",
+		}, runSpec(In(newYork, spec), start, 3))
+	})
+}
+
 func TestScheduleSpec_MultipleSpecs(t *testing.T) {
 	s := Schedule(newDummyRunnable(), Every(time.Hour), HourlyAt(30))
 
@@ -100,6 +358,21 @@ func TestScheduleSpec_MultipleSpecs(t *testing.T) {
 	require.Equal(t, want.String(), got.String())
 }
 
+func TestScheduleSpec_NeverFires(t *testing.T) {
+	never := func(_, _ time.Time) time.Time { return time.Time{} }
+
+	s := Schedule(newDummyRunnable(), never, HourlyAt(30))
+
+	now := time.Date(2025, 1, 1, 14, 20, 0, 0, time.UTC)
+	want := time.Date(2025, 1, 1, 14, 30, 0, 0, time.UTC)
+	require.Equal(t, want.String(), s.nextTime(now, now).String())
+
+	s = Schedule(newDummyRunnable(), never)
+	require.True(t, s.nextTime(now, now).IsZero())
+
+	AssertRunnableRespectCancellation(t, s, time.Second)
+}
+
 func TestSchedule_Cancellation(t *testing.T) {
 	runner := Schedule(newDummyRunnable(), Every(time.Second))
 
@@ -218,6 +491,567 @@ func TestSchedule_ErrorStopsLoop(t *testing.T) {
 	})
 }
 
+func TestSchedule_ErrorLimit(t *testing.T) {
+	t.Run("unlimited", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			var count atomic.Int64
+			worker := Func(func(ctx context.Context) error {
+				count.Add(1)
+				return &dummyError{message: "task failed"}
+			})
+
+			var failures int
+			s := Schedule(worker, Every(time.Second)).
+				ErrorLimit(0).
+				OnEvent(func(e ScheduleEvent) {
+					require.Equal(t, ScheduleFailed, e.Kind)
+					require.EqualError(t, e.Err, "task failed")
+					failures++
+				})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5500*time.Millisecond)
+			defer cancel()
+
+			err := s.Run(ctx)
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+			require.Equal(t, int64(5), count.Load())
+			require.Equal(t, 5, failures)
+		})
+	})
+
+	t.Run("consecutive failures", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			// Fails on runs 1, 2, 4, 5 and 6: the success on run 3 resets the count.
+			var count atomic.Int64
+			worker := Func(func(ctx context.Context) error {
+				if count.Add(1) == 3 {
+					return nil
+				}
+				return &dummyError{message: "task failed"}
+			})
+
+			err := Schedule(worker, Every(time.Second)).ErrorLimit(3).Run(context.Background())
+			require.EqualError(t, err, "task failed")
+			require.Equal(t, int64(6), count.Load())
+		})
+	})
+}
+
+func TestSchedule_Retry(t *testing.T) {
+	t.Run("retried run succeeds", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			start := time.Now()
+
+			var calls []time.Duration
+			worker := Func(func(ctx context.Context) error {
+				calls = append(calls, time.Since(start))
+				if len(calls)%3 != 0 {
+					return &dummyError{message: "transient"}
+				}
+				return nil
+			})
+
+			s := Schedule(worker, Every(time.Hour)).Retry(3, func(attempt int) time.Duration {
+				return time.Duration(attempt) * time.Minute
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Hour+5*time.Minute)
+			defer cancel()
+
+			err := s.Run(ctx)
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+
+			// Two runs, each succeeding on the third attempt, still aligned on the hour.
+			require.Equal(t, []time.Duration{
+				time.Hour, time.Hour + time.Minute, time.Hour + 3*time.Minute,
+				2 * time.Hour, 2*time.Hour + time.Minute, 2*time.Hour + 3*time.Minute,
+			}, calls)
+		})
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			var count atomic.Int64
+			worker := Func(func(ctx context.Context) error {
+				count.Add(1)
+				return &dummyError{message: "task failed"}
+			})
+
+			err := Schedule(worker, Every(time.Second)).Retry(2, nil).Run(context.Background())
+			require.EqualError(t, err, "task failed")
+			require.Equal(t, int64(3), count.Load())
+		})
+	})
+}
+
+func TestSchedule_Timeout(t *testing.T) {
+	t.Run("timeout stops the schedule", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			worker := Func(func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}).Name("hung")
+
+			var events []ScheduleEvent
+			s := Schedule(worker, Every(time.Hour)).
+				Timeout(time.Minute).
+				OnEvent(func(e ScheduleEvent) { events = append(events, e) })
+
+			start := time.Now()
+			err := s.Run(context.Background())
+
+			var timeoutErr *TimeoutError
+			require.ErrorAs(t, err, &timeoutErr)
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+			require.EqualError(t, err, "schedule/hung: timed out after 1m0s")
+			require.Equal(t, time.Hour+time.Minute, time.Since(start))
+
+			require.Len(t, events, 1)
+			require.Equal(t, ScheduleTimedOut, events[0].Kind)
+		})
+	})
+
+	t.Run("timeouts with error limit and retries", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			var count atomic.Int64
+			worker := Func(func(ctx context.Context) error {
+				if count.Add(1)%2 == 1 {
+					<-ctx.Done() // hangs every other attempt
+					return ctx.Err()
+				}
+				return nil
+			})
+
+			var events []ScheduleEventKind
+			s := Schedule(worker, Every(time.Hour)).
+				Timeout(time.Minute).
+				Retry(1, nil).
+				OnEvent(func(e ScheduleEvent) { events = append(events, e.Kind) })
+
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Hour+30*time.Minute)
+			defer cancel()
+
+			err := s.Run(ctx)
+			require.EqualError(t, err, "context deadline exceeded") // not a TimeoutError
+
+			// Each run times out once, and succeeds on retry.
+			require.Equal(t, int64(6), count.Load())
+			require.Empty(t, events)
+		})
+	})
+
+	t.Run("parent cancellation is not a timeout", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			worker := Func(func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Hour+time.Second)
+			defer cancel()
+
+			err := Schedule(worker, Every(time.Hour)).Timeout(time.Minute).Run(ctx)
+			require.EqualError(t, err, "context deadline exceeded") // not a TimeoutError
+		})
+	})
+}
+
+// startTimes runs the schedule until the timeout and returns the start times of the
+// executions, relative to the start of the schedule.
+func startTimes(t *testing.T, s func(Runnable) *schedule, timeout time.Duration) []time.Duration {
+	t.Helper()
+
+	start := time.Now()
+	var starts []time.Duration
+	worker := Func(func(ctx context.Context) error {
+		starts = append(starts, time.Since(start))
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := s(worker).Run(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	return starts
+}
+
+func TestSchedule_Clock(t *testing.T) {
+	t.Run("daily", func(t *testing.T) {
+		clock := runnabletest.NewClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		runs := make(chan time.Time)
+		worker := Func(func(ctx context.Context) error {
+			runs <- clock.Now()
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() { done <- Schedule(worker, DailyAt(3, 0)).Clock(clock).Run(ctx) }()
+
+		for day := 1; day <= 3; day++ {
+			want := time.Date(2025, 1, day, 3, 0, 0, 0, time.UTC)
+
+			clock.BlockUntil(1)
+			require.Equal(t, []time.Time{want}, clock.Timers())
+
+			clock.Set(want)
+			require.Equal(t, want, <-runs)
+		}
+
+		cancel()
+		require.ErrorIs(t, <-done, context.Canceled)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		clock := runnabletest.NewClock(start)
+
+		worker := Func(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}).Name("hung")
+
+		done := make(chan error)
+		go func() {
+			done <- Schedule(worker, Every(time.Hour)).Timeout(time.Minute).Clock(clock).Run(context.Background())
+		}()
+
+		clock.BlockUntil(1)
+		clock.Advance(time.Hour)
+
+		// The next tick and the timeout of the run.
+		clock.BlockUntil(2)
+		require.Equal(t, []time.Time{start.Add(time.Hour + time.Minute), start.Add(2 * time.Hour)}, clock.Timers())
+
+		clock.Advance(time.Minute)
+		require.EqualError(t, <-done, "schedule/hung: timed out after 1m0s")
+	})
+
+	t.Run("pause and resume", func(t *testing.T) {
+		start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		clock := runnabletest.NewClock(start)
+
+		worker := Func(func(ctx context.Context) error { return nil })
+		s := Schedule(worker, Every(time.Hour)).Clock(clock)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() { done <- s.Run(ctx) }()
+
+		clock.BlockUntil(1)
+
+		// The timer of the next tick is stopped while paused.
+		s.Pause()
+		require.Eventually(t, func() bool { return len(clock.Timers()) == 0 }, time.Second, time.Millisecond)
+
+		s.Resume()
+		clock.BlockUntil(1)
+		require.Equal(t, []time.Time{start.Add(time.Hour)}, clock.Timers())
+
+		cancel()
+		require.ErrorIs(t, <-done, context.Canceled)
+		require.Empty(t, clock.Timers())
+	})
+}
+
+func TestSchedule_Control(t *testing.T) {
+	t.Run("trigger, pause and resume", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			var mu sync.Mutex
+			var runs []time.Time
+			worker := Func(func(ctx context.Context) error {
+				mu.Lock()
+				defer mu.Unlock()
+				runs = append(runs, time.Now())
+				return nil
+			})
+			getRuns := func() []time.Time {
+				synctest.Wait()
+				mu.Lock()
+				defer mu.Unlock()
+				return slices.Clone(runs)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error)
+
+			start := time.Now()
+			s := Schedule(worker, Every(time.Hour))
+			go func() { done <- s.Run(ctx) }()
+
+			synctest.Wait()
+			require.Equal(t, start.Add(time.Hour), s.Next())
+
+			// Triggering does not move the next tick.
+			s.Trigger()
+			require.Equal(t, []time.Time{start}, getRuns())
+			require.Equal(t, start.Add(time.Hour), s.Next())
+
+			time.Sleep(30 * time.Minute)
+			s.Pause()
+			synctest.Wait()
+			require.True(t, s.Next().IsZero())
+
+			time.Sleep(3 * time.Hour)
+			require.Len(t, getRuns(), 1)
+
+			// An interval elapsed while paused: runs right away.
+			s.Resume()
+			require.Equal(t, []time.Time{start, start.Add(3*time.Hour + 30*time.Minute)}, getRuns())
+			require.Equal(t, start.Add(4*time.Hour+30*time.Minute), s.Next())
+
+			cancel()
+			require.ErrorIs(t, <-done, context.Canceled)
+			require.True(t, s.Next().IsZero())
+		})
+	})
+
+	t.Run("trigger applies the overlap policy", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			worker := Func(func(ctx context.Context) error {
+				time.Sleep(10 * time.Minute)
+				return nil
+			})
+
+			var events []ScheduleEventKind
+			s := Schedule(worker, DailyAt(3, 0)).
+				OnEvent(func(e ScheduleEvent) { events = append(events, e.Kind) })
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+			defer cancel()
+			go func() {
+				synctest.Wait()
+				s.Trigger()
+				synctest.Wait()
+				s.Trigger()
+			}()
+
+			require.ErrorIs(t, s.Run(ctx), context.DeadlineExceeded)
+			require.Equal(t, []ScheduleEventKind{ScheduleSkipped}, events)
+		})
+	})
+
+	t.Run("paused before running", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			counter := newCounterRunnable()
+			s := Schedule(counter, Every(time.Minute)).RunOnStart()
+			s.Pause()
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+			defer cancel()
+
+			require.ErrorIs(t, s.Run(ctx), context.DeadlineExceeded)
+			require.Equal(t, 0, counter.counter)
+		})
+	})
+}
+
+func TestSchedule_RunOnStart(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		starts := startTimes(t, func(r Runnable) *schedule {
+			return Schedule(r, Every(time.Hour)).RunOnStart()
+		}, 150*time.Minute)
+
+		require.Equal(t, []time.Duration{0, time.Hour, 2 * time.Hour}, starts)
+	})
+}
+
+func TestSchedule_InitialDelay(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		starts := startTimes(t, func(r Runnable) *schedule {
+			return Schedule(r, Every(time.Hour)).InitialDelay(10 * time.Minute)
+		}, 150*time.Minute)
+		require.Equal(t, []time.Duration{70 * time.Minute, 130 * time.Minute}, starts)
+
+		starts = startTimes(t, func(r Runnable) *schedule {
+			return Schedule(r, Every(time.Hour)).InitialDelay(10 * time.Minute).RunOnStart()
+		}, 150*time.Minute)
+		require.Equal(t, []time.Duration{10 * time.Minute, 70 * time.Minute, 130 * time.Minute}, starts)
+	})
+}
+
+func TestSchedule_Jitter(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		starts := startTimes(t, func(r Runnable) *schedule {
+			return Schedule(r, Every(time.Hour)).Jitter(time.Minute)
+		}, 10*time.Hour+time.Minute)
+
+		require.Len(t, starts, 10)
+		for i, start := range starts {
+			planned := time.Duration(i+1) * time.Hour // the jitter does not accumulate
+			require.GreaterOrEqual(t, start, planned)
+			require.Less(t, start, planned+time.Minute)
+		}
+	})
+}
+
+func TestSchedule_Splay(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		splayed := func(instance string) []time.Duration {
+			return startTimes(t, func(r Runnable) *schedule {
+				return Schedule(r, Every(time.Hour)).Splay(10*time.Minute, instance)
+			}, 3*time.Hour)
+		}
+
+		a := splayed("host-a")
+		require.Len(t, a, 2)
+		offset := a[0] - time.Hour
+		require.Less(t, offset, 10*time.Minute)
+		require.Equal(t, 2*time.Hour+offset, a[1])
+
+		require.Equal(t, a, splayed("host-a"))
+		require.NotEqual(t, a, splayed("host-b"))
+	})
+}
+
+func TestSchedule_PanicIsReturned(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		worker := Func(func(ctx context.Context) error {
+			panic("boom")
+		})
+
+		err := Schedule(worker, Every(time.Second)).Run(context.Background())
+		require.ErrorAs(t, err, new(*PanicError))
+	})
+}
+
+// overlapTest runs a worker taking 24s every 10s for 60s, and returns the number of
+// executions started and the events.
+func overlapTest(t *testing.T, configure func(*schedule)) (int64, []ScheduleEventKind) {
+	t.Helper()
+
+	var started atomic.Int64
+	var events []ScheduleEventKind
+
+	worker := Func(func(ctx context.Context) error {
+		started.Add(1)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(24 * time.Second):
+			return nil
+		}
+	})
+
+	s := Schedule(worker, Every(10*time.Second)).OnEvent(func(e ScheduleEvent) {
+		events = append(events, e.Kind)
+	})
+	configure(s)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 65*time.Second)
+	defer cancel()
+
+	err := s.Run(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	return started.Load(), events
+}
+
+func TestSchedule_Overlap(t *testing.T) {
+	t.Run("wait (default)", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			// Starts at 10s, 34s and 58s, right after the previous run completed.
+			started, events := overlapTest(t, func(s *schedule) {})
+			require.Equal(t, int64(3), started)
+			require.Empty(t, events)
+		})
+	})
+
+	t.Run("wait runs right after a slow run", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			start := time.Now()
+			var starts []time.Duration
+			worker := Func(func(ctx context.Context) error {
+				starts = append(starts, time.Since(start))
+				if len(starts) == 1 {
+					time.Sleep(61 * time.Minute)
+				}
+				return nil
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Hour+30*time.Minute)
+			defer cancel()
+
+			err := Schedule(worker, Every(time.Hour)).Run(ctx)
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+			require.Equal(t, []time.Duration{
+				time.Hour, 2*time.Hour + time.Minute, 3*time.Hour + time.Minute,
+			}, starts)
+		})
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			// Starts at 10s and 40s; ticks at 20s, 30s, 50s and 60s are skipped.
+			started, events := overlapTest(t, func(s *schedule) { s.Overlap(OverlapSkip) })
+			require.Equal(t, int64(2), started)
+			require.Equal(t, []ScheduleEventKind{
+				ScheduleSkipped, ScheduleSkipped, ScheduleSkipped, ScheduleSkipped,
+			}, events)
+		})
+	})
+
+	t.Run("queue", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			// Starts at 10s, 34s (queued at 20s) and 58s (queued at 40s). Queued again at 60s.
+			started, events := overlapTest(t, func(s *schedule) { s.Overlap(OverlapQueue) })
+			require.Equal(t, int64(3), started)
+			require.Equal(t, []ScheduleEventKind{
+				ScheduleQueued, ScheduleSkipped, ScheduleQueued, ScheduleSkipped, ScheduleQueued,
+			}, events)
+		})
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			started, events := overlapTest(t, func(s *schedule) { s.Overlap(OverlapConcurrent) })
+			require.Equal(t, int64(6), started)
+			require.Empty(t, events)
+		})
+	})
+
+	t.Run("concurrent with limit", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			// Starts at 10s and 20s; 30s is skipped; starts at 40s and 50s; 60s is skipped.
+			started, events := overlapTest(t, func(s *schedule) { s.Overlap(OverlapConcurrent).MaxConcurrent(2) })
+			require.Equal(t, int64(4), started)
+			require.Equal(t, []ScheduleEventKind{ScheduleSkipped, ScheduleSkipped}, events)
+		})
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			// Each tick cancels the running execution and starts a new one.
+			started, events := overlapTest(t, func(s *schedule) { s.Overlap(OverlapReplace) })
+			require.Equal(t, int64(6), started)
+			require.Equal(t, []ScheduleEventKind{
+				ScheduleCancelled, ScheduleCancelled, ScheduleCancelled, ScheduleCancelled, ScheduleCancelled,
+			}, events)
+		})
+	})
+
+	t.Run("running executions are cancelled on error", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			var cancelled atomic.Bool
+			var calls atomic.Int64
+			worker := Func(func(ctx context.Context) error {
+				if calls.Add(1) == 2 {
+					return &dummyError{message: "task failed"}
+				}
+				<-ctx.Done()
+				cancelled.Store(true)
+				return ctx.Err()
+			})
+
+			err := Schedule(worker, Every(time.Second)).Overlap(OverlapConcurrent).Run(context.Background())
+			require.EqualError(t, err, "task failed")
+			require.True(t, cancelled.Load())
+		})
+	})
+}
+
 func itoa(n int64) string {
 	if n == 0 {
 		return "0"
@@ -22,7 +22,9 @@ func (r *httpServer) runnableName() string { return r.name }
 //
 // On context cancellation, it calls [http.Server.Shutdown] to gracefully drain
 // in-flight requests before returning. The shutdown timeout defaults to 30 seconds
-// and can be configured with [httpServer.ShutdownTimeout].
+// and can be configured with [httpServer.ShutdownTimeout]. When the shutdown is forced
+// by a repeated signal (see [signal.Escalate]), the remaining connections are closed,
+// and Run returns [ErrShutdownForced].
 func HTTPServer(server *http.Server) *httpServer {
 	return &httpServer{
 		name:            "httpserver",
@@ -58,7 +60,7 @@ func (r *httpServer) Run(ctx context.Context) error {
 	select {
 	case <-ctx.Done():
 		logger.Info(r.name + ": shutting down")
-		shutdownErr = r.shutdown()
+		shutdownErr = r.shutdown(shutdownForced(ctx))
 		err = <-errChan
 		logger.Info(r.name + ": stopped")
 	case err = <-errChan:
@@ -78,10 +80,34 @@ func (r *httpServer) Run(ctx context.Context) error {
 	return nil
 }
 
-func (r *httpServer) shutdown() error {
+// shutdown gracefully shuts down the server, and closes the remaining connections
+// when the shutdown is forced.
+func (r *httpServer) shutdown(force <-chan struct{}) error {
 	ctx := context.Background() // only used for timeout in Shutdown.
-	ctx, cancel := context.WithTimeout(ctx, r.shutdownTimeout)
+	ctx, cancel := context.WithTimeoutCause(ctx, r.shutdownTimeout, &ShutdownTimeoutError{r.name, r.shutdownTimeout})
 	defer cancel()
 
-	return r.server.Shutdown(ctx)
+	go func() {
+		select {
+		case <-force:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	err := r.server.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = context.Cause(ctx)
+	}
+
+	select {
+	case <-force:
+		logger.Info(r.name + ": shutdown forced, closing connections")
+		if err == nil || errors.Is(err, context.Canceled) {
+			err = ErrShutdownForced
+		}
+		return errors.Join(err, r.server.Close())
+	default:
+		return err
+	}
 }
@@ -2,6 +2,7 @@ package runnable
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -107,6 +108,109 @@ func TestHTTPServer(t *testing.T) {
 	})
 }
 
+// stuckServer returns a server on an available port, with a handler that only returns
+// when its connection is closed. The channel receives a value when a request started.
+func stuckServer(t *testing.T) (*http.Server, <-chan struct{}) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	_ = ln.Close()
+
+	started := make(chan struct{}, 1)
+	server := &http.Server{
+		Addr: addr,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			started <- struct{}{}
+			<-r.Context().Done()
+		}),
+	}
+	return server, started
+}
+
+// stuckRequest sends a request to the server, retrying until it is listening, and
+// returns a channel receiving the error of the request.
+func stuckRequest(server *http.Server) <-chan error {
+	errChan := make(chan error, 1)
+	go func() {
+		for {
+			resp, err := http.Get("http://" + server.Addr)
+			var opErr *net.OpError
+			if errors.As(err, &opErr) && opErr.Op == "dial" {
+				time.Sleep(10 * time.Millisecond)
+				continue
+			}
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+			errChan <- err
+			return
+		}
+	}()
+	return errChan
+}
+
+func TestHTTPServer_ShutdownForced(t *testing.T) {
+	t.Run("closes the connections", func(t *testing.T) {
+		server, started := stuckServer(t)
+
+		force := make(chan struct{})
+		ctx, cancel := context.WithCancel(context.WithValue(context.Background(), forceKey{}, force))
+
+		errChan := make(chan error, 1)
+		go func() { errChan <- HTTPServer(server).Run(ctx) }()
+
+		requestErr := stuckRequest(server)
+		<-started
+
+		cancel()
+		require.Never(t, func() bool { return len(errChan) > 0 }, 50*time.Millisecond, 10*time.Millisecond)
+
+		close(force)
+		select {
+		case err := <-errChan:
+			require.ErrorIs(t, err, ErrShutdownForced)
+			require.NotErrorIs(t, err, context.Canceled)
+			require.EqualError(t, err, "server shutdown: shutdown forced")
+		case <-time.After(5 * time.Second):
+			t.Fatal("server did not return within 5s")
+		}
+
+		require.Error(t, <-requestErr) // the connection was closed
+	})
+
+	t.Run("in a manager", func(t *testing.T) {
+		server, started := stuckServer(t)
+
+		m := Manager().ShutdownTimeout(time.Hour)
+		m.Register(HTTPServer(server))
+
+		force := make(chan struct{})
+		ctx, cancel := context.WithCancel(context.WithValue(context.Background(), forceKey{}, force))
+
+		errChan := make(chan error, 1)
+		go func() { errChan <- m.Run(ctx) }()
+
+		requestErr := stuckRequest(server)
+		<-started
+
+		cancel()
+		close(force)
+
+		// The manager stops waiting for the server as soon as the shutdown is forced,
+		// unless the server already returned: either way, the forced shutdown is an error.
+		err := <-errChan
+		if errors.Is(err, ErrShutdownForced) {
+			require.EqualError(t, err, "manager: httpserver crashed with server shutdown: shutdown forced")
+		} else {
+			require.EqualError(t, err, "manager: httpserver is still running")
+		}
+
+		require.Error(t, <-requestErr)
+	})
+}
+
 func ExampleHTTPServer() {
 	ctx, cancel := initializeForExample()
 	defer cancel()
@@ -2,13 +2,26 @@ package runnable
 
 import (
 	"context"
+	"io"
 	"os"
 	ossignal "os/signal"
+	"sync/atomic"
 	"syscall"
+	"time"
 )
 
+// osExit is replaced in tests.
+var osExit = os.Exit
+
 // Signal returns a runnable that runs the runnable and cancels it when the process receives a POSIX signal.
-func Signal(runnable Runnable, signals ...os.Signal) Runnable {
+// The cause of the cancellation is a [*SignalError].
+//
+// By default, the signals are no longer handled once received, so that a repeated signal
+// terminates the process. Use [signal.Escalate] to handle repeated signals instead.
+//
+// Use [signal.ReloadOn] to reload the runnable on a signal, like SIGHUP, and
+// [signal.DumpOn] to dump the goroutines and the state of the runnables.
+func Signal(runnable Runnable, signals ...os.Signal) *signal {
 	if len(signals) == 0 {
 		signals = append(signals, syscall.SIGINT)
 		signals = append(signals, syscall.SIGTERM)
@@ -18,6 +31,7 @@ func Signal(runnable Runnable, signals ...os.Signal) Runnable {
 		name:     "signal/" + runnableName(runnable),
 		runnable: runnable,
 		signals:  signals,
+		clock:    systemClock{},
 	}
 }
 
@@ -25,23 +39,199 @@ type signal struct {
 	name     string
 	runnable Runnable
 	signals  []os.Signal
+	escalate bool
+	exitCode int
+	reloadOn []os.Signal
+	dumpOn   []os.Signal
+	dumpTo   io.Writer
+	preStop  time.Duration
+	clock    Clock
+
+	shuttingDown atomic.Bool
+	received     atomic.Pointer[SignalError] // cause of the cancellation, if by a signal
 }
 
 func (s *signal) runnableName() string { return s.name }
+func (s *signal) unwrap() Runnable     { return s.runnable }
+
+// Escalate handles repeated signals during the shutdown. The second signal forces the
+// shutdown: managers stop waiting for their runnables as if their shutdown timeouts
+// expired, and HTTP servers close their connections. The third signal exits the process
+// immediately with the given exit code.
+func (s *signal) Escalate(exitCode int) *signal {
+	s.escalate = true
+	s.exitCode = exitCode
+	return s
+}
+
+// PreStopDelay delays the cancellation of the runnable after the signal, while the
+// shutdown is reported by [signal.ShuttingDown], so that a readiness check can fail and
+// load balancers stop sending traffic first. A repeated signal during the delay skips it,
+// without counting toward [signal.Escalate].
+func (s *signal) PreStopDelay(d time.Duration) *signal {
+	s.preStop = d
+	return s
+}
+
+// Clock sets the clock used for the pre-stop delay. Defaults to the system clock.
+func (s *signal) Clock(clock Clock) *signal {
+	s.clock = clock
+	return s
+}
+
+// ShuttingDown reports whether a signal was received, including during the pre-stop
+// delay. It is safe for concurrent use, like from a readiness check.
+func (s *signal) ShuttingDown() bool {
+	return s.shuttingDown.Load()
+}
+
+// ReloadOn reloads the runnable with [Reload] when the process receives one of the
+// signals, without cancelling it. Defaults to SIGHUP. Reload errors are logged.
+func (s *signal) ReloadOn(signals ...os.Signal) *signal {
+	if len(signals) == 0 {
+		signals = append(signals, syscall.SIGHUP)
+	}
+	s.reloadOn = signals
+	return s
+}
+
+// DumpOn writes the state of the runnables and the stacks of all goroutines to w when
+// the process receives one of the signals, without cancelling the runnable. Defaults to
+// SIGQUIT, replacing the default dump and exit of the Go runtime. The goroutines of
+// the runnables started by a [Manager] are labelled with their name.
+func (s *signal) DumpOn(w io.Writer, signals ...os.Signal) *signal {
+	if len(signals) == 0 {
+		signals = append(signals, syscall.SIGQUIT)
+	}
+	s.dumpOn = signals
+	s.dumpTo = w
+	return s
+}
 
 func (s *signal) Run(ctx context.Context) error {
-	ctx, cancelFunc := context.WithCancel(ctx)
+	ctx, cancelFunc := context.WithCancelCause(ctx)
+	defer cancelFunc(nil)
+
+	force := make(chan struct{})
+	if s.escalate {
+		ctx = context.WithValue(ctx, forceKey{}, force)
+	}
 
 	sigChan := make(chan os.Signal, 1)
 	ossignal.Notify(sigChan, s.signals...)
+	defer ossignal.Stop(sigChan)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	s.shuttingDown.Store(false)
+	s.received.Store(nil)
 
 	go func() {
-		defer ossignal.Reset(s.signals...)
+		for received := 1; ; received++ {
+			var sig os.Signal
+			select {
+			case <-done:
+				return
+			case sig = <-sigChan:
+			}
 
-		sig := <-sigChan
-		logger.Info(s.name+": received signal", "signal", sig)
-		cancelFunc()
+			switch received {
+			case 1:
+				logger.Info(s.name+": received signal", "signal", sig)
+				s.shuttingDown.Store(true)
+				if s.preStop > 0 && !s.preStopDelay(sigChan, done) {
+					return
+				}
+				cause := &SignalError{sig}
+				s.received.Store(cause)
+				cancelFunc(cause)
+				if !s.escalate {
+					ossignal.Reset(s.signals...)
+					return
+				}
+			case 2:
+				logger.Info(s.name+": received second signal, forcing shutdown", "signal", sig)
+				close(force)
+			default:
+				logger.Info(s.name+": received third signal, exiting", "signal", sig, "code", s.exitCode)
+				osExit(s.exitCode)
+				return
+			}
+		}
 	}()
 
+	defer s.handle(s.reloadOn, done, func(sig os.Signal) {
+		logger.Info(s.name+": received signal, reloading", "signal", sig)
+		if err := Reload(ctx, s.runnable); err != nil {
+			logger.Info(s.name+": reload failed", "error", err)
+		}
+	})()
+
+	defer s.handle(s.dumpOn, done, func(sig os.Signal) {
+		logger.Info(s.name+": received signal, dumping", "signal", sig)
+		if err := writeDump(s.dumpTo, s.runnable); err != nil {
+			logger.Info(s.name+": dump failed", "error", err)
+		}
+	})()
+
 	return s.runnable.Run(ctx)
 }
+
+// signalled returns the cause of the cancellation of the last run, if it was cancelled
+// by a signal.
+func (s *signal) signalled() *SignalError {
+	return s.received.Load()
+}
+
+// preStopDelay waits for the pre-stop delay, or until a repeated signal. It returns
+// false when the runnable returned in the meantime.
+func (s *signal) preStopDelay(sigChan <-chan os.Signal, done <-chan struct{}) bool {
+	logger.Info(s.name+": delaying shutdown", "delay", s.preStop)
+
+	delay, stop := s.clock.NewTimer(s.preStop)
+	defer stop()
+
+	select {
+	case <-done:
+		return false
+	case sig := <-sigChan:
+		logger.Info(s.name+": received signal again, skipping delay", "signal", sig)
+	case <-delay:
+	}
+	return true
+}
+
+// handle calls fn for each of the signals received, until done. It returns a function
+// that stops listening to the signals.
+func (s *signal) handle(signals []os.Signal, done <-chan struct{}, fn func(os.Signal)) func() {
+	if len(signals) == 0 {
+		return func() {}
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	ossignal.Notify(sigChan, signals...)
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case sig := <-sigChan:
+				fn(sig)
+			}
+		}
+	}()
+
+	return func() { ossignal.Stop(sigChan) }
+}
+
+// forceKey is the context key of the channel closed when the shutdown is forced.
+type forceKey struct{}
+
+// shutdownForced returns a channel closed when the shutdown is forced by a repeated
+// signal (see [signal.Escalate]), or nil without escalation.
+func shutdownForced(ctx context.Context) <-chan struct{} {
+	force, _ := ctx.Value(forceKey{}).(chan struct{})
+	return force
+}
@@ -0,0 +1,231 @@
+//go:build unix
+
+package runnable
+
+import (
+	"context"
+	"os"
+	ossignal "os/signal"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/pior/runnable/runnabletest"
+)
+
+func sendSignal(t *testing.T, sig syscall.Signal) {
+	t.Helper()
+	require.NoError(t, syscall.Kill(syscall.Getpid(), sig))
+}
+
+func TestSignal(t *testing.T) {
+	started := make(chan struct{})
+	worker := Func(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return context.Cause(ctx)
+	})
+
+	done := make(chan error)
+	go func() { done <- Signal(worker, syscall.SIGUSR1).Run(context.Background()) }()
+
+	<-started
+	sendSignal(t, syscall.SIGUSR1)
+
+	err := <-done
+	require.ErrorIs(t, err, context.Canceled)
+	require.EqualError(t, err, "received signal user defined signal 1")
+
+	var signalErr *SignalError
+	require.ErrorAs(t, err, &signalErr)
+	require.Equal(t, syscall.SIGUSR1, signalErr.Signal)
+}
+
+func TestSignal_Escalate(t *testing.T) {
+	exitCode := make(chan int, 1)
+	exited := make(chan struct{})
+	defer func(exit func(int)) { osExit = exit }(osExit)
+	osExit = func(code int) {
+		exitCode <- code
+		close(exited)
+	}
+
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	forced := make(chan struct{})
+	worker := Func(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		close(cancelled)
+		<-shutdownForced(ctx)
+		close(forced)
+		<-exited // wedged until the process exits
+		return nil
+	})
+
+	done := make(chan error)
+	go func() { done <- Signal(worker, syscall.SIGUSR1).Escalate(3).Run(context.Background()) }()
+
+	<-started
+	sendSignal(t, syscall.SIGUSR1)
+	<-cancelled
+	sendSignal(t, syscall.SIGUSR1)
+	<-forced
+	sendSignal(t, syscall.SIGUSR1)
+
+	select {
+	case code := <-exitCode:
+		require.Equal(t, 3, code)
+	case <-time.After(time.Second):
+		t.Fatal("process did not exit")
+	}
+	require.NoError(t, <-done)
+}
+
+func TestSignal_ReloadOn(t *testing.T) {
+	r := &reloadable{}
+
+	// Keep the signal from terminating the process until the runnable is listening.
+	caught := make(chan os.Signal, 1)
+	ossignal.Notify(caught, syscall.SIGUSR2)
+	defer ossignal.Stop(caught)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- Signal(r, syscall.SIGUSR1).ReloadOn(syscall.SIGUSR2).Run(ctx) }()
+
+	require.Eventually(t, func() bool {
+		sendSignal(t, syscall.SIGUSR2)
+		return r.reloads.Load() > 0
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestSignal_DumpOn(t *testing.T) {
+	var dump lockedBuffer
+
+	// Keep the signal from terminating the process until the runnable is listening.
+	caught := make(chan os.Signal, 1)
+	ossignal.Notify(caught, syscall.SIGUSR2)
+	defer ossignal.Stop(caught)
+
+	m := Manager()
+	m.Register(newDummyRunnable())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- Signal(m, syscall.SIGUSR1).DumpOn(&dump, syscall.SIGUSR2).Run(ctx) }()
+
+	require.Eventually(t, func() bool {
+		sendSignal(t, syscall.SIGUSR2)
+		return strings.Contains(dump.String(), "goroutines:")
+	}, time.Second, 10*time.Millisecond)
+
+	require.Contains(t, dump.String(), "  manager: running\n")
+	require.Contains(t, dump.String(), `"runnable":"manager/dummyRunnable"`)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestSignal_PreStopDelay(t *testing.T) {
+	newWorker := func() (*funcRunnable, <-chan struct{}) {
+		started := make(chan struct{})
+		return Func(func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		}), started
+	}
+
+	t.Run("delay", func(t *testing.T) {
+		clock := runnabletest.NewClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+		worker, started := newWorker()
+		s := Signal(worker, syscall.SIGUSR1).PreStopDelay(10 * time.Second).Clock(clock)
+
+		done := make(chan error)
+		go func() { done <- s.Run(context.Background()) }()
+
+		<-started
+		require.False(t, s.ShuttingDown())
+
+		sendSignal(t, syscall.SIGUSR1)
+		clock.BlockUntil(1)
+		require.True(t, s.ShuttingDown())
+
+		clock.Advance(9 * time.Second)
+		require.Empty(t, done)
+
+		clock.Advance(time.Second)
+		require.ErrorIs(t, <-done, context.Canceled)
+	})
+
+	t.Run("repeated signal skips the delay", func(t *testing.T) {
+		worker, started := newWorker()
+		s := Signal(worker, syscall.SIGUSR1).PreStopDelay(time.Hour)
+
+		done := make(chan error)
+		go func() { done <- s.Run(context.Background()) }()
+
+		<-started
+		sendSignal(t, syscall.SIGUSR1)
+		require.Eventually(t, s.ShuttingDown, time.Second, time.Millisecond)
+
+		sendSignal(t, syscall.SIGUSR1)
+		require.ErrorIs(t, <-done, context.Canceled)
+	})
+}
+
+func TestMain_Signal(t *testing.T) {
+	for _, sig := range []syscall.Signal{syscall.SIGINT, syscall.SIGTERM} {
+		t.Run(sig.String(), func(t *testing.T) {
+			started := make(chan struct{})
+			worker := Func(func(ctx context.Context) error {
+				close(started)
+				<-ctx.Done()
+				return ctx.Err()
+			})
+
+			m := Manager()
+			m.Register(worker, newDummyRunnable())
+
+			code := make(chan int)
+			go func() { code <- Main(m) }()
+
+			<-started // the signals are handled once the runnable runs
+			sendSignal(t, sig)
+
+			require.Equal(t, 128+int(sig), <-code)
+		})
+	}
+}
+
+func TestRun_Signal(t *testing.T) {
+	defer func(exit func(int)) { osExit = exit }(osExit)
+	exited := make(chan int, 1)
+	osExit = func(code int) { exited <- code }
+
+	started := make(chan struct{})
+	worker := Func(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		RunGroup(worker, newDummyRunnable())
+	}()
+
+	<-started // the signals are handled once the runnable runs
+	sendSignal(t, syscall.SIGTERM)
+
+	<-done
+	require.Empty(t, exited) // a clean shutdown after a signal exits with 0
+}
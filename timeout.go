@@ -0,0 +1,94 @@
+package runnable
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Timeout returns a runnable that runs the runnable with a deadline, for one-shot jobs
+// like migrations and backfills.
+//
+// When the deadline is exceeded, the context of the runnable is cancelled and Timeout
+// returns a [*TimeoutError] once the runnable returned with an error. A runnable that
+// ignores the cancellation is waited for, unless a grace period is set with
+// [timeout.Grace]. Cancellation of the parent context is not a timeout: its error is
+// returned as is.
+func Timeout(runnable Runnable, d time.Duration) *timeout {
+	return &timeout{
+		name:     "timeout/" + runnableName(runnable),
+		runnable: runnable,
+		timeout:  d,
+		clock:    systemClock{},
+	}
+}
+
+type timeout struct {
+	name     string
+	runnable Runnable
+	timeout  time.Duration
+	grace    time.Duration
+	clock    Clock
+}
+
+var _ Runnable = (*timeout)(nil)
+
+func (t *timeout) runnableName() string { return t.name }
+func (t *timeout) unwrap() Runnable     { return t.runnable }
+
+// Grace sets how long to wait for the runnable to return once the deadline is exceeded.
+// After the grace period, Timeout returns a [*TimeoutError] without waiting for the
+// runnable, leaving it running in the background. Defaults to 0: wait indefinitely.
+func (t *timeout) Grace(d time.Duration) *timeout {
+	t.grace = d
+	return t
+}
+
+// Clock sets the clock used for the deadline and the grace period. Defaults to the
+// system clock.
+func (t *timeout) Clock(clock Clock) *timeout {
+	t.clock = clock
+	return t
+}
+
+func (t *timeout) Run(ctx context.Context) error {
+	runCtx, cancel := withTimeout(ctx, t.clock, t.timeout)
+	defer cancel()
+
+	if t.grace <= 0 {
+		return t.result(ctx, runCtx, t.runnable.Run(runCtx))
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- t.runnable.Run(runCtx) }()
+
+	select {
+	case err := <-done:
+		return t.result(ctx, runCtx, err)
+	case <-runCtx.Done():
+	}
+
+	if ctx.Err() != nil {
+		return t.result(ctx, runCtx, <-done)
+	}
+
+	grace, stop := t.clock.NewTimer(t.grace)
+	defer stop()
+
+	select {
+	case err := <-done:
+		return t.result(ctx, runCtx, err)
+	case <-grace:
+		logger.Info(t.name+": still running after the grace period, abandoning", "timeout", t.timeout, "grace", t.grace)
+		return &TimeoutError{t.name, t.timeout}
+	}
+}
+
+// result returns the error of the runnable, as a [*TimeoutError] if it failed because
+// the deadline was exceeded.
+func (t *timeout) result(ctx, runCtx context.Context, err error) error {
+	if err != nil && ctx.Err() == nil && errors.Is(context.Cause(runCtx), context.DeadlineExceeded) {
+		return &TimeoutError{t.name, t.timeout}
+	}
+	return err
+}
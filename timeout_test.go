@@ -0,0 +1,118 @@
+package runnable
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeout(t *testing.T) {
+	t.Run("completes in time", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			worker := Func(func(ctx context.Context) error {
+				time.Sleep(time.Minute)
+				return nil
+			})
+
+			err := Timeout(worker, time.Hour).Run(context.Background())
+			require.NoError(t, err)
+		})
+	})
+
+	t.Run("error in time", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			worker := Func(func(ctx context.Context) error { return errors.New("boom") })
+
+			err := Timeout(worker, time.Hour).Run(context.Background())
+			require.EqualError(t, err, "boom")
+		})
+	})
+
+	t.Run("deadline exceeded", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			worker := Func(func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}).Name("migration")
+
+			start := time.Now()
+			err := Timeout(worker, 5*time.Minute).Run(context.Background())
+
+			var timeoutErr *TimeoutError
+			require.ErrorAs(t, err, &timeoutErr)
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+			require.EqualError(t, err, "timeout/migration: timed out after 5m0s")
+			require.Equal(t, 5*time.Minute, time.Since(start))
+		})
+	})
+
+	t.Run("parent cancellation is not a timeout", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			worker := Func(func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			err := Timeout(worker, time.Hour).Grace(time.Second).Run(ctx)
+			require.EqualError(t, err, "context deadline exceeded") // not a TimeoutError
+		})
+	})
+}
+
+func TestTimeout_Grace(t *testing.T) {
+	t.Run("returns within the grace period", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			worker := Func(func(ctx context.Context) error {
+				<-ctx.Done()
+				time.Sleep(time.Second) // cleanup
+				return ctx.Err()
+			})
+
+			start := time.Now()
+			err := Timeout(worker, time.Minute).Grace(10 * time.Second).Run(context.Background())
+
+			require.ErrorAs(t, err, new(*TimeoutError))
+			require.Equal(t, time.Minute+time.Second, time.Since(start))
+		})
+	})
+
+	t.Run("abandons after the grace period", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			release := make(chan struct{})
+			defer close(release)
+
+			worker := Func(func(ctx context.Context) error {
+				<-release // ignores the cancellation
+				return nil
+			}).Name("backfill")
+
+			start := time.Now()
+			err := Timeout(worker, time.Minute).Grace(10 * time.Second).Run(context.Background())
+
+			require.EqualError(t, err, "timeout/backfill: timed out after 1m0s")
+			require.Equal(t, time.Minute+10*time.Second, time.Since(start))
+		})
+	})
+
+	t.Run("waits indefinitely without grace period", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			worker := Func(func(ctx context.Context) error {
+				time.Sleep(time.Hour) // ignores the cancellation
+				return nil
+			})
+
+			start := time.Now()
+			err := Timeout(worker, time.Minute).Run(context.Background())
+
+			require.NoError(t, err)
+			require.Equal(t, time.Hour, time.Since(start))
+		})
+	})
+}
@@ -1,6 +1,9 @@
 package runnable
 
-import "context"
+import (
+	"context"
+	"os"
+)
 
 // Noop returns a runnable that does nothing, and return when the context is cancelled.
 func Noop() Runnable {
@@ -9,3 +12,23 @@ func Noop() Runnable {
 		return ctx.Err()
 	})
 }
+
+// writeTempFile writes data to a new temporary file in dir, and passes its path to
+// publish, like [os.Rename] or [os.Link], so that the published file is never seen
+// partially written. The temporary file is removed afterwards.
+func writeTempFile(dir, pattern string, data []byte, publish func(tmp string) error) error {
+	tmp, err := os.CreateTemp(dir, pattern)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return publish(tmp.Name())
+}